@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -24,6 +25,7 @@ type Config struct {
 	BaseURL                     string
 	IsLambda                    bool
 	StorageConnectionStringBase string
+	LegacyWebhookURLs           bool // Accept webhook URLs with a raw chat ID instead of a token
 }
 
 func GetLambdaURL() (string, error) {
@@ -97,6 +99,9 @@ func Initialize() error {
 		return fmt.Errorf("SECRET_KEY environment variable is missing")
 	}
 
+	// Old webhook URLs exposed the numeric chat ID; only accept them if explicitly enabled
+	legacyWebhookURLs, _ := strconv.ParseBool(os.Getenv("LEGACY_WEBHOOK_URLS"))
+
 	// Auto-detect storage connection string base
 	var storageConnectionStringBase string
 	if mongoURL := os.Getenv("MONGO_URL"); mongoURL != "" {
@@ -115,6 +120,7 @@ func Initialize() error {
 		BaseURL:                     baseURL,
 		IsLambda:                    isLambda,
 		StorageConnectionStringBase: storageConnectionStringBase,
+		LegacyWebhookURLs:           legacyWebhookURLs,
 	}
 
 	return nil
@@ -0,0 +1,106 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// BranchFilter decides which branches events are reported for.
+// A nil BranchFilter matches all branches.
+type BranchFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// ParseBranchFilter builds a branch filter from the values of the repeated branch query parameter.
+//
+// Each value is one of:
+//   - a branch name, e.g. main
+//   - a glob pattern, where * matches any characters except /, ** matches any characters
+//     and ? matches a single character except /, e.g. release/*
+//   - a regular expression enclosed in slashes, e.g. /^v\d+$/
+//
+// Values prefixed with ! exclude the matching branches. Returns nil if there are no values.
+func ParseBranchFilter(values []string) (*BranchFilter, error) {
+	var f BranchFilter
+	for _, value := range values {
+		pattern, excluded := strings.CutPrefix(value, "!")
+		if pattern == "" {
+			continue
+		}
+
+		re, err := compilePattern(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid branch filter %q: %w", value, err)
+		}
+
+		if excluded {
+			f.exclude = append(f.exclude, re)
+		} else {
+			f.include = append(f.include, re)
+		}
+	}
+
+	if len(f.include) == 0 && len(f.exclude) == 0 {
+		return nil, nil
+	}
+	return &f, nil
+}
+
+// Match reports whether events for the branch should be reported.
+// Excluded branches never match. Otherwise, the branch matches if it matches any of
+// the included patterns, or if there are only exclusions.
+func (f *BranchFilter) Match(branch string) bool {
+	if f == nil {
+		return true
+	}
+
+	for _, re := range f.exclude {
+		if re.MatchString(branch) {
+			return false
+		}
+	}
+
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, re := range f.include {
+		if re.MatchString(branch) {
+			return true
+		}
+	}
+	return false
+}
+
+// compilePattern compiles a branch name, glob pattern or /regular expression/
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		return regexp.Compile(pattern[1 : len(pattern)-1])
+	}
+	return regexp.Compile(globToRegexp(pattern))
+}
+
+// globToRegexp converts a glob pattern to an anchored regular expression
+func globToRegexp(pattern string) string {
+	var re strings.Builder
+
+	re.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**"):
+			re.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			re.WriteString("[^/]*")
+		case pattern[i] == '?':
+			re.WriteString("[^/]")
+		default:
+			// QuoteMeta only escapes ASCII characters, so multi-byte characters are kept intact
+			re.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	re.WriteString("$")
+
+	return re.String()
+}
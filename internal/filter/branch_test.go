@@ -0,0 +1,112 @@
+package filter
+
+import "testing"
+
+func TestBranchFilterMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		matches map[string]bool
+	}{
+		{
+			name:    "no values match all branches",
+			values:  nil,
+			matches: map[string]bool{"main": true, "feature/x": true},
+		},
+		{
+			name:    "empty values are ignored",
+			values:  []string{"", "!"},
+			matches: map[string]bool{"main": true},
+		},
+		{
+			name:    "branch name is an exact match",
+			values:  []string{"main"},
+			matches: map[string]bool{"main": true, "main2": false, "x/main": false},
+		},
+		{
+			name:    "repeated values match any of them",
+			values:  []string{"main", "develop"},
+			matches: map[string]bool{"main": true, "develop": true, "feature/x": false},
+		},
+		{
+			name:    "single star doesn't match slashes",
+			values:  []string{"release/*"},
+			matches: map[string]bool{"release/1.0": true, "release/a/b": false, "release": false},
+		},
+		{
+			name:    "double star matches slashes",
+			values:  []string{"release/**"},
+			matches: map[string]bool{"release/1.0": true, "release/a/b": true},
+		},
+		{
+			name:    "question mark matches a single character except slash",
+			values:  []string{"v?"},
+			matches: map[string]bool{"v1": true, "v10": false, "v/": false},
+		},
+		{
+			name:    "glob escapes regexp metacharacters",
+			values:  []string{"release-1.0", "fix(+)"},
+			matches: map[string]bool{"release-1.0": true, "release-100": false, "fix(+)": true, "fixx": false},
+		},
+		{
+			name:    "exclusion only matches everything else",
+			values:  []string{"!dependabot/**"},
+			matches: map[string]bool{"main": true, "dependabot/npm/lodash": false},
+		},
+		{
+			name:    "exclusion wins over inclusion",
+			values:  []string{"main", "release/*", "!release/legacy"},
+			matches: map[string]bool{"main": true, "release/1.0": true, "release/legacy": false, "develop": false},
+		},
+		{
+			name:    "regexp between slashes",
+			values:  []string{`/^v\d+$/`},
+			matches: map[string]bool{"v1": true, "v12": true, "v1.2": false, "xv1": false},
+		},
+		{
+			name:    "unanchored regexp matches anywhere",
+			values:  []string{"/hotfix/"},
+			matches: map[string]bool{"hotfix": true, "team/hotfix-1": true, "main": false},
+		},
+		{
+			name:    "excluded regexp",
+			values:  []string{"!/^tmp-/"},
+			matches: map[string]bool{"tmp-1": false, "main": true},
+		},
+		{
+			name:    "single slash is a branch name",
+			values:  []string{"/"},
+			matches: map[string]bool{"/": true, "main": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseBranchFilter(tt.values)
+			if err != nil {
+				t.Fatalf("ParseBranchFilter(%q) failed: %v", tt.values, err)
+			}
+			for branch, want := range tt.matches {
+				if got := f.Match(branch); got != want {
+					t.Errorf("ParseBranchFilter(%q).Match(%q) = %v, want %v", tt.values, branch, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseBranchFilterNoValues(t *testing.T) {
+	f, err := ParseBranchFilter([]string{"", "!"})
+	if err != nil {
+		t.Fatalf("ParseBranchFilter failed: %v", err)
+	}
+	if f != nil {
+		t.Errorf("ParseBranchFilter returned %+v, want nil", f)
+	}
+}
+
+func TestParseBranchFilterInvalidRegexp(t *testing.T) {
+	if _, err := ParseBranchFilter([]string{"/(/"}); err == nil {
+		t.Error("ParseBranchFilter accepted an invalid regexp")
+	}
+}
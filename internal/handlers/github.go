@@ -6,8 +6,8 @@ import (
 	"log"
 	"net/http"
 
+	"git-telegram-bot/internal/filter"
 	"git-telegram-bot/internal/services/github"
-	telegramBase "git-telegram-bot/internal/services/telegram"
 	telegram "git-telegram-bot/internal/services/telegram/github"
 
 	"github.com/gorilla/mux"
@@ -26,18 +26,31 @@ func NewGitHubHandler(telegramSvc *telegram.GitHubTelegramService, githubSvc *gi
 }
 
 func (h *GitHubHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
-	// Get chat ID from URL
-	vars := mux.Vars(r)
-	chatID, err := telegramBase.ParseChatID(vars["chatID"])
-	if err != nil {
-		http.Error(w, "Failed to parse chatID from URL", http.StatusBadRequest)
+	// Resolve chat ID from the webhook URL
+	webhookKey := mux.Vars(r)["webhookKey"]
+	chatID, ok := resolveWebhookChatID(w, h.telegramSvc.TelegramService, webhookKey)
+	if !ok {
 		return
 	}
 
-	// Get branch filter from query parameters if present
-	branchFilter := r.URL.Query().Get("branch")
-	// Check if project name should be included in messages
-	includeProject := r.URL.Query().Get("project") != ""
+	// Get event options from query parameters
+	query := r.URL.Query()
+	branchFilter, err := filter.ParseBranchFilter(query["branch"])
+	if err != nil {
+		log.Printf("Invalid branch filter: %v", err)
+		http.Error(w, "Invalid branch filter", http.StatusBadRequest)
+		return
+	}
+	opts := github.EventOptions{
+		// Branch filter, if present
+		BranchFilter: branchFilter,
+		// Whether project name should be included in messages
+		IncludeProject: query.Get("project") != "",
+		// Whether community events should be reported
+		IncludeCommunity: query.Get("community") != "",
+		// Whether unsupported events should be reported
+		IncludeUnknown: query.Get("unknown") != "",
+	}
 
 	// Read request body
 	body, err := io.ReadAll(r.Body)
@@ -47,6 +60,12 @@ func (h *GitHubHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Verify payload signature with the chat's webhook secret (legacy webhooks have none)
+	if !h.telegramSvc.IsLegacyWebhookKey(webhookKey) &&
+		!verifyWebhook(w, r, body, h.telegramSvc.GetWebhookSecret(webhookKey), verifyGitHubSignature) {
+		return
+	}
+
 	// Get event type from GitHub headers
 	eventType := r.Header.Get("X-GitHub-Event")
 	if eventType == "" {
@@ -56,7 +75,7 @@ func (h *GitHubHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse GitHub event
-	if err := h.githubSvc.HandleEvent(chatID, eventType, body, branchFilter, includeProject); err != nil {
+	if err := h.githubSvc.HandleEvent(chatID, eventType, body, opts); err != nil {
 		log.Printf("Failed to parse GitHub event: %v", err)
 		http.Error(w, "Failed to parse GitHub event", http.StatusBadRequest)
 		return
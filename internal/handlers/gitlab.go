@@ -6,8 +6,8 @@ import (
 	"log"
 	"net/http"
 
+	"git-telegram-bot/internal/filter"
 	"git-telegram-bot/internal/services/gitlab"
-	telegramBase "git-telegram-bot/internal/services/telegram"
 	telegram "git-telegram-bot/internal/services/telegram/gitlab"
 
 	"github.com/gorilla/mux"
@@ -26,16 +26,35 @@ func NewGitLabHandler(telegramSvc *telegram.GitLabTelegramService, gitlabSvc *gi
 }
 
 func (h *GitLabHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
-	// Get chat ID from URL
-	vars := mux.Vars(r)
-	chatID, err := telegramBase.ParseChatID(vars["chatID"])
-	if err != nil {
-		http.Error(w, "Failed to parse chatID from URL", http.StatusBadRequest)
+	// Resolve chat ID from the webhook URL
+	webhookKey := mux.Vars(r)["webhookKey"]
+	chatID, ok := resolveWebhookChatID(w, h.telegramSvc.TelegramService, webhookKey)
+	if !ok {
 		return
 	}
 
-	// Check if project name should be included in messages
-	includeProject := r.URL.Query().Get("project") != ""
+	// Get event options from query parameters
+	query := r.URL.Query()
+	branchFilter, err := filter.ParseBranchFilter(query["branch"])
+	if err != nil {
+		log.Printf("Invalid branch filter: %v", err)
+		http.Error(w, "Invalid branch filter", http.StatusBadRequest)
+		return
+	}
+	opts := gitlab.EventOptions{
+		// Branch filter, if present
+		BranchFilter: branchFilter,
+		// Whether project name should be included in messages
+		IncludeProject: query.Get("project") != "",
+		// Whether the full project path should be included instead, for group webhooks
+		IncludeProjectPath: query.Get("project") == "path",
+		// Whether unsupported events should be reported
+		IncludeUnknown: query.Get("unknown") != "",
+		// Whether failed jobs should be alerted separately
+		IncludeFailedJobs: query.Get("failed_jobs") != "",
+		// Whether reactions should be reported
+		IncludeEmoji: query.Get("emoji") != "",
+	}
 
 	// Read request body
 	body, err := io.ReadAll(r.Body)
@@ -45,6 +64,12 @@ func (h *GitLabHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Verify secret token with the chat's webhook secret (legacy webhooks have none)
+	if !h.telegramSvc.IsLegacyWebhookKey(webhookKey) &&
+		!verifyWebhook(w, r, body, h.telegramSvc.GetWebhookSecret(webhookKey), verifyGitLabToken) {
+		return
+	}
+
 	// Get event type from GitLab headers
 	eventType := r.Header.Get("X-Gitlab-Event")
 	if eventType == "" {
@@ -53,7 +78,7 @@ func (h *GitLabHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.gitlabSvc.HandleEvent(chatID, eventType, body, includeProject); err != nil {
+	if err := h.gitlabSvc.HandleEvent(chatID, eventType, body, opts); err != nil {
 		log.Printf("Failed to handle GitLab event: %v", err)
 		http.Error(w, "Failed to handle GitLab event", http.StatusInternalServerError)
 		return
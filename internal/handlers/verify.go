@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"git-telegram-bot/internal/services/telegram"
+)
+
+// resolveWebhookChatID finds the chat behind a webhook key, responding with 404 if there is none
+func resolveWebhookChatID(w http.ResponseWriter, telegramSvc *telegram.TelegramService, webhookKey string) (int64, bool) {
+	chatID, err := telegramSvc.ResolveWebhookChatID(webhookKey)
+	if errors.Is(err, telegram.ErrUnknownWebhook) {
+		http.Error(w, "Unknown webhook", http.StatusNotFound)
+		return 0, false
+	}
+	if err != nil {
+		log.Printf("Failed to resolve webhook: %v", err)
+		http.Error(w, "Failed to resolve webhook", http.StatusInternalServerError)
+		return 0, false
+	}
+	return chatID, true
+}
+
+// webhookVerifier checks that a webhook delivery was sent with the chat's secret
+type webhookVerifier func(r *http.Request, body []byte, secret string) error
+
+// verifyWebhook runs the verifier and responds with 401 if the delivery can't be trusted
+func verifyWebhook(w http.ResponseWriter, r *http.Request, body []byte, secret string, verify webhookVerifier) bool {
+	if err := verify(r, body, secret); err != nil {
+		log.Printf("Rejected webhook delivery to %s: %v", r.URL.Path, err)
+		http.Error(w, "Invalid webhook secret", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+// verifyGitHubSignature checks the "sha256=<hex>" HMAC of the payload sent by GitHub
+func verifyGitHubSignature(r *http.Request, body []byte, secret string) error {
+	signature := r.Header.Get("X-Hub-Signature-256")
+	if signature == "" {
+		return errors.New("missing X-Hub-Signature-256 header")
+	}
+	hexDigest, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return errors.New("malformed X-Hub-Signature-256 header")
+	}
+	digest, err := hex.DecodeString(hexDigest)
+	if err != nil {
+		return errors.New("malformed X-Hub-Signature-256 header")
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), digest) {
+		return errors.New("X-Hub-Signature-256 mismatch")
+	}
+	return nil
+}
+
+// verifyGitLabToken checks the secret token sent by GitLab in plain text
+func verifyGitLabToken(r *http.Request, body []byte, secret string) error {
+	token := r.Header.Get("X-Gitlab-Token")
+	if token == "" {
+		return errors.New("missing X-Gitlab-Token header")
+	}
+	if !hmac.Equal([]byte(token), []byte(secret)) {
+		return errors.New("X-Gitlab-Token mismatch")
+	}
+	return nil
+}
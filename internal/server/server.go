@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"log"
 	"net/http"
@@ -41,6 +42,9 @@ func New() (*Server, error) {
 		}
 	}).Methods("GET")
 
+	// Diagnostics endpoint (e.g. counters of unsupported webhook events)
+	router.HandleFunc("/debug/vars", requireSecretKey(expvar.Handler().ServeHTTP)).Methods("GET")
+
 	// Setup GitHub service
 	githubTelegramSvc, err := telegramGithub.NewGitHubTelegramService(storageInstance)
 	if err != nil {
@@ -51,7 +55,7 @@ func New() (*Server, error) {
 
 		// GitHub webhook endpoint
 		githubHandler := handlers.NewGitHubHandler(githubTelegramSvc, githubSvc)
-		router.HandleFunc("/github/{chatID}", githubHandler.HandleWebhook).Methods("POST")
+		router.HandleFunc("/github/{webhookKey}", githubHandler.HandleWebhook).Methods("POST")
 
 		// GitHub Telegram bot webhook endpoint
 		router.HandleFunc("/telegram/webhook/github", githubTelegramSvc.WebhookHandler()).Methods("POST")
@@ -67,7 +71,7 @@ func New() (*Server, error) {
 
 		// GitLab webhook endpoint
 		gitlabHandler := handlers.NewGitLabHandler(gitlabTelegramSvc, gitlabSvc)
-		router.HandleFunc("/gitlab/{chatID}", gitlabHandler.HandleWebhook).Methods("POST")
+		router.HandleFunc("/gitlab/{webhookKey}", gitlabHandler.HandleWebhook).Methods("POST")
 
 		// GitLab Telegram bot webhook endpoint
 		router.HandleFunc("/telegram/webhook/gitlab", gitlabTelegramSvc.WebhookHandler()).Methods("POST")
@@ -90,18 +94,7 @@ func New() (*Server, error) {
 
 	if config.Global.IsLambda {
 		// In AWS Lambda, init bots once after deploy (otherwise this runs on every cold start)
-		router.HandleFunc("/init", func(w http.ResponseWriter, r *http.Request) {
-			// Validate secret key from header
-			secretKey := r.Header.Get("secret-key")
-			if secretKey != config.Global.SecretKey {
-				log.Printf("Invalid secret key provided for init endpoint")
-				w.WriteHeader(http.StatusUnauthorized)
-				if _, writeErr := w.Write([]byte("Unauthorized: Invalid secret key")); writeErr != nil {
-					log.Printf("Failed to write response: %v", writeErr)
-				}
-				return
-			}
-
+		router.HandleFunc("/init", requireSecretKey(func(w http.ResponseWriter, r *http.Request) {
 			if err := initBots(); err != nil {
 				log.Printf("%v", err)
 				w.WriteHeader(http.StatusInternalServerError)
@@ -115,7 +108,7 @@ func New() (*Server, error) {
 			if _, err := w.Write([]byte("Telegram bots successfully initialized.")); err != nil {
 				log.Printf("Failed to write response: %v", err)
 			}
-		}).Methods("GET")
+		})).Methods("GET")
 	} else {
 		// On local development, init bots on app start
 		if err := initBots(); err != nil {
@@ -135,6 +128,23 @@ func New() (*Server, error) {
 	}, nil
 }
 
+// requireSecretKey protects an internal endpoint with the secret key passed in the "secret-key" header
+func requireSecretKey(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Validate secret key from header
+		secretKey := r.Header.Get("secret-key")
+		if secretKey != config.Global.SecretKey {
+			log.Printf("Invalid secret key provided for %s endpoint", r.URL.Path)
+			w.WriteHeader(http.StatusUnauthorized)
+			if _, writeErr := w.Write([]byte("Unauthorized: Invalid secret key")); writeErr != nil {
+				log.Printf("Failed to write response: %v", writeErr)
+			}
+			return
+		}
+		handler(w, r)
+	}
+}
+
 func (s *Server) Router() *mux.Router {
 	return s.router
 }
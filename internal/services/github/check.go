@@ -0,0 +1,246 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+
+	"git-telegram-bot/internal/filter"
+	"git-telegram-bot/internal/storage"
+)
+
+// GitHub Actions report their own checks, which are covered by workflow_run and workflow_job events
+const githubActionsAppSlug = "github-actions"
+
+// checkCommit identifies a checks message, one per repository commit
+type checkCommit struct {
+	HeadSHA    string
+	HeadBranch string
+	Repository string
+	HTMLURL    string // Commit URL
+}
+
+func (s *GitHubService) handleCheckRunEvent(chatID int64, payload []byte, branchFilter *filter.BranchFilter, includeProject bool) error {
+	var event struct {
+		Action   string `json:"action"`
+		CheckRun struct {
+			ID         int64  `json:"id"`
+			Name       string `json:"name"`
+			HeadSHA    string `json:"head_sha"`
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+			App        struct {
+				Name string `json:"name"`
+				Slug string `json:"slug"`
+			} `json:"app"`
+			CheckSuite struct {
+				HeadBranch string `json:"head_branch"`
+			} `json:"check_suite"`
+		} `json:"check_run"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on known check run actions
+	if event.Action != "created" &&
+		event.Action != "rerequested" &&
+		event.Action != "completed" {
+		return nil
+	}
+
+	checkRun := event.CheckRun
+	if checkRun.App.Slug == githubActionsAppSlug {
+		return nil
+	}
+
+	// If branch filter is specified and doesn't match the suite's branch, skip this event
+	if !branchFilter.Match(checkRun.CheckSuite.HeadBranch) {
+		return nil
+	}
+
+	commit := checkCommit{
+		HeadSHA:    checkRun.HeadSHA,
+		HeadBranch: checkRun.CheckSuite.HeadBranch,
+		Repository: event.Repository.FullName,
+		HTMLURL:    fmt.Sprintf("%s/commit/%s", event.Repository.HTMLURL, checkRun.HeadSHA),
+	}
+
+	// Completed check runs are described by their conclusion, others by their status
+	status := checkRun.Status
+	if status == "completed" {
+		status = checkRun.Conclusion
+	}
+
+	return s.telegramSvc.UpdatePipelineMessage(chatID, commit.HTMLURL+"/checks", func(pipeline *storage.Pipeline) string {
+		pipeline.SetJob(storage.PipelineJob{
+			ID:     checkRun.ID,
+			Name:   checkRun.Name,
+			Status: status,
+			Group:  checkRun.App.Name,
+		})
+		return formatChecksMessage(commit, pipeline, includeProject)
+	})
+}
+
+func (s *GitHubService) handleCheckSuiteEvent(chatID int64, payload []byte, branchFilter *filter.BranchFilter, includeProject bool) error {
+	var event struct {
+		Action     string `json:"action"`
+		CheckSuite struct {
+			ID         int64  `json:"id"`
+			HeadSHA    string `json:"head_sha"`
+			HeadBranch string `json:"head_branch"`
+			Conclusion string `json:"conclusion"`
+			App        struct {
+				Name string `json:"name"`
+				Slug string `json:"slug"`
+			} `json:"app"`
+		} `json:"check_suite"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// GitHub requests check suites from every installed app, even those that never report.
+	// Only completed suites are reported to not show them as pending forever.
+	if event.Action != "completed" {
+		return nil
+	}
+
+	checkSuite := event.CheckSuite
+	if checkSuite.App.Slug == githubActionsAppSlug {
+		return nil
+	}
+
+	// If branch filter is specified and doesn't match the suite's branch, skip this event
+	if !branchFilter.Match(checkSuite.HeadBranch) {
+		return nil
+	}
+
+	commit := checkCommit{
+		HeadSHA:    checkSuite.HeadSHA,
+		HeadBranch: checkSuite.HeadBranch,
+		Repository: event.Repository.FullName,
+		HTMLURL:    fmt.Sprintf("%s/commit/%s", event.Repository.HTMLURL, checkSuite.HeadSHA),
+	}
+
+	return s.telegramSvc.UpdatePipelineMessage(chatID, commit.HTMLURL+"/checks", func(pipeline *storage.Pipeline) string {
+		// Suites are stored without a name (and with negated IDs to not clash with check runs),
+		// and only shown for apps that didn't report check runs
+		pipeline.SetJob(storage.PipelineJob{
+			ID:     -checkSuite.ID,
+			Status: checkSuite.Conclusion,
+			Group:  checkSuite.App.Name,
+		})
+		return formatChecksMessage(commit, pipeline, includeProject)
+	})
+}
+
+// formatChecksMessage renders a summary of the check runs known so far for a commit, one line per app
+func formatChecksMessage(commit checkCommit, pipeline *storage.Pipeline, includeProject bool) string {
+	// Group check runs by app
+	var apps []string
+	checkRuns := map[string][]storage.PipelineJob{}
+	checkSuites := map[string][]storage.PipelineJob{}
+	for _, job := range pipeline.Jobs {
+		if !slices.Contains(apps, job.Group) {
+			apps = append(apps, job.Group)
+		}
+		if job.Name != "" {
+			checkRuns[job.Group] = append(checkRuns[job.Group], job)
+		} else {
+			checkSuites[job.Group] = append(checkSuites[job.Group], job)
+		}
+	}
+
+	// The overall status is the "worst" status of what's shown
+	var statuses []string
+	var lines []string
+	for _, app := range apps {
+		jobs := checkRuns[app]
+		if len(jobs) == 0 {
+			jobs = checkSuites[app]
+		}
+
+		var summary []string
+		for _, job := range jobs {
+			statuses = append(statuses, job.Status)
+			if job.Name != "" {
+				summary = append(summary, workflowStatusEmoji(job.Status)+" "+html.EscapeString(job.Name))
+			} else {
+				summary = append(summary, workflowStatusEmoji(job.Status)+" "+html.EscapeString(strings.ReplaceAll(job.Status, "_", " ")))
+			}
+		}
+		lines = append(lines, fmt.Sprintf("<b>%s</b>: %s", html.EscapeString(app), strings.Join(summary, ", ")))
+	}
+	status := checksStatus(statuses)
+
+	var message strings.Builder
+
+	message.WriteString(workflowStatusEmoji(status) + " ")
+	if includeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(commit.Repository)))
+	}
+
+	message.WriteString(fmt.Sprintf(
+		"Checks %s for <a href=\"%s\"><code>%s</code></a>",
+		checksStatusDisplay(status),
+		commit.HTMLURL,
+		html.EscapeString(shortSHA(commit.HeadSHA)),
+	))
+	if commit.HeadBranch != "" {
+		message.WriteString(fmt.Sprintf(" on <code>%s</code>", html.EscapeString(commit.HeadBranch)))
+	}
+
+	message.WriteString(":\n")
+	for _, line := range lines {
+		message.WriteString(line + "\n")
+	}
+
+	return message.String()
+}
+
+// checksStatus combines statuses of check runs into one: running if any is unfinished, otherwise failed if any failed
+func checksStatus(statuses []string) string {
+	status := "success"
+	for _, jobStatus := range statuses {
+		switch jobStatus {
+		case "queued", "requested", "pending", "waiting", "in_progress":
+			return "in_progress"
+		case "failure", "timed_out", "action_required", "cancelled":
+			status = "failure"
+		}
+	}
+	return status
+}
+
+func checksStatusDisplay(status string) string {
+	switch status {
+	case "in_progress":
+		return "running"
+	case "failure":
+		return "failed"
+	default:
+		return "passed"
+	}
+}
+
+// shortSHA returns the abbreviated commit hash as shown by GitHub
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,229 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"git-telegram-bot/internal/services/telegram"
+)
+
+func (s *GitHubService) handleDiscussionEvent(chatID int64, payload []byte, includeProject bool) error {
+	var event struct {
+		Action     string `json:"action"`
+		Discussion struct {
+			Number   int    `json:"number"`
+			Title    string `json:"title"`
+			HTMLURL  string `json:"html_url"`
+			Body     string `json:"body"`
+			Category struct {
+				Name string `json:"name"`
+			} `json:"category"`
+		} `json:"discussion"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+		Sender struct {
+			Login string `json:"login"`
+		} `json:"sender"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on known discussion actions
+	if event.Action != "created" &&
+		event.Action != "answered" &&
+		event.Action != "closed" &&
+		event.Action != "reopened" {
+		return nil
+	}
+
+	// Build message
+	var message strings.Builder
+
+	// Add emoji based on action
+	var emoji string
+	var action string
+	switch event.Action {
+	case "created":
+		emoji = "🗣️"
+		action = "started"
+	case "answered":
+		emoji = "✅"
+		action = "answered"
+	case "closed":
+		emoji = "🔒"
+		action = "closed"
+	case "reopened":
+		emoji = "🔄"
+		action = "reopened"
+	default:
+		emoji = "ℹ️"
+		action = event.Action
+	}
+
+	message.WriteString(emoji + " ")
+	if includeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(event.Repository.FullName)))
+	}
+
+	message.WriteString(fmt.Sprintf(
+		"<b>%s</b> %s discussion <a href=\"%s\">#%d %s</a> in <b>%s</b>.",
+		html.EscapeString(event.Sender.Login),
+		action,
+		event.Discussion.HTMLURL,
+		event.Discussion.Number,
+		html.EscapeString(event.Discussion.Title),
+		html.EscapeString(event.Discussion.Category.Name),
+	))
+
+	// Show what the new discussion is about
+	if event.Action == "created" {
+		if excerpt := telegram.FormatExcerpt(event.Discussion.Body); excerpt != "" {
+			message.WriteString("\n" + excerpt)
+		}
+	}
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
+
+func (s *GitHubService) handleDiscussionCommentEvent(chatID int64, payload []byte, includeProject bool) error {
+	var event struct {
+		Action  string `json:"action"`
+		Comment struct {
+			Body    string `json:"body"`
+			HTMLURL string `json:"html_url"`
+		} `json:"comment"`
+		Discussion struct {
+			Number int    `json:"number"`
+			Title  string `json:"title"`
+		} `json:"discussion"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+		Sender struct {
+			Login string `json:"login"`
+		} `json:"sender"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on new comments
+	if event.Action != "created" {
+		return nil
+	}
+
+	// Build message
+	var message strings.Builder
+
+	message.WriteString("💬 ")
+	if includeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(event.Repository.FullName)))
+	}
+
+	message.WriteString(fmt.Sprintf(
+		"<b>%s</b> commented on discussion <a href=\"%s\">#%d %s</a>.",
+		html.EscapeString(event.Sender.Login),
+		event.Comment.HTMLURL,
+		event.Discussion.Number,
+		html.EscapeString(event.Discussion.Title),
+	))
+
+	if excerpt := telegram.FormatExcerpt(event.Comment.Body); excerpt != "" {
+		message.WriteString("\n" + excerpt)
+	}
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
+
+// handleStarEvent handles "star" events and their legacy "watch" counterpart, which GitHub sends when a repository is starred
+func (s *GitHubService) handleStarEvent(chatID int64, payload []byte, includeProject bool) error {
+	var event struct {
+		Action     string `json:"action"`
+		Repository struct {
+			FullName        string `json:"full_name"`
+			HTMLURL         string `json:"html_url"`
+			StargazersCount int    `json:"stargazers_count"`
+		} `json:"repository"`
+		Sender struct {
+			Login   string `json:"login"`
+			HTMLURL string `json:"html_url"`
+		} `json:"sender"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on new stars ("created" for star events, "started" for watch events)
+	if event.Action != "created" && event.Action != "started" {
+		return nil
+	}
+
+	// Build message
+	var message strings.Builder
+
+	message.WriteString("⭐ ")
+	if includeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(event.Repository.FullName)))
+	}
+
+	message.WriteString(fmt.Sprintf(
+		"<a href=\"%s\"><b>%s</b></a> starred <a href=\"%s\">%s</a> (%d stars).",
+		event.Sender.HTMLURL,
+		html.EscapeString(event.Sender.Login),
+		event.Repository.HTMLURL,
+		html.EscapeString(event.Repository.FullName),
+		event.Repository.StargazersCount,
+	))
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
+
+func (s *GitHubService) handleForkEvent(chatID int64, payload []byte, includeProject bool) error {
+	var event struct {
+		Forkee struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"forkee"`
+		Repository struct {
+			FullName   string `json:"full_name"`
+			HTMLURL    string `json:"html_url"`
+			ForksCount int    `json:"forks_count"`
+		} `json:"repository"`
+		Sender struct {
+			Login string `json:"login"`
+		} `json:"sender"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Build message
+	var message strings.Builder
+
+	message.WriteString("🍴 ")
+	if includeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(event.Repository.FullName)))
+	}
+
+	message.WriteString(fmt.Sprintf(
+		"<b>%s</b> forked <a href=\"%s\">%s</a> to <a href=\"%s\">%s</a> (%d forks).",
+		html.EscapeString(event.Sender.Login),
+		event.Repository.HTMLURL,
+		html.EscapeString(event.Repository.FullName),
+		event.Forkee.HTMLURL,
+		html.EscapeString(event.Forkee.FullName),
+		event.Repository.ForksCount,
+	))
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
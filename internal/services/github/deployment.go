@@ -0,0 +1,190 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"git-telegram-bot/internal/filter"
+	"git-telegram-bot/internal/storage"
+)
+
+// deployment holds what's needed to render a deployment message from deployment and deployment_status events
+type deployment struct {
+	ID             int64
+	URL            string // API URL, identifies the deployment message
+	Ref            string
+	Environment    string
+	EnvironmentURL string
+	Creator        string
+	Repository     string
+	HTMLURL        string // Link to the deployment (log or the repository deployments page)
+}
+
+// deploymentPayload is the part of the payload shared by deployment and deployment_status events
+type deploymentPayload struct {
+	Deployment struct {
+		ID          int64  `json:"id"`
+		URL         string `json:"url"`
+		Ref         string `json:"ref"`
+		Environment string `json:"environment"`
+		Creator     struct {
+			Login string `json:"login"`
+		} `json:"creator"`
+	} `json:"deployment"`
+	Repository struct {
+		FullName string `json:"full_name"`
+		HTMLURL  string `json:"html_url"`
+	} `json:"repository"`
+}
+
+func (p *deploymentPayload) toDeployment() deployment {
+	return deployment{
+		ID:          p.Deployment.ID,
+		URL:         p.Deployment.URL,
+		Ref:         p.Deployment.Ref,
+		Environment: p.Deployment.Environment,
+		Creator:     p.Deployment.Creator.Login,
+		Repository:  p.Repository.FullName,
+		HTMLURL:     p.Repository.HTMLURL + "/deployments",
+	}
+}
+
+func (s *GitHubService) handleDeploymentEvent(chatID int64, payload []byte, branchFilter *filter.BranchFilter, includeProject bool) error {
+	var event struct {
+		Action string `json:"action"`
+		deploymentPayload
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on new deployments
+	if event.Action != "created" {
+		return nil
+	}
+
+	// If branch filter is specified and doesn't match the deployed ref (a branch, tag or commit SHA), skip this event
+	if !branchFilter.Match(event.Deployment.Ref) {
+		return nil
+	}
+
+	d := event.toDeployment()
+
+	// Edit the deployment's existing message as statuses arrive, or create a new one
+	return s.telegramSvc.UpdatePipelineMessage(chatID, d.URL, func(pipeline *storage.Pipeline) string {
+		// The first status may be reported before the deployment itself
+		if pipeline.Status == "" {
+			pipeline.Status = "created"
+		}
+		return formatDeploymentMessage(d, pipeline, includeProject)
+	})
+}
+
+func (s *GitHubService) handleDeploymentStatusEvent(chatID int64, payload []byte, branchFilter *filter.BranchFilter, includeProject bool) error {
+	var event struct {
+		Action           string `json:"action"`
+		DeploymentStatus struct {
+			State          string `json:"state"`
+			LogURL         string `json:"log_url"`
+			TargetURL      string `json:"target_url"`
+			EnvironmentURL string `json:"environment_url"`
+		} `json:"deployment_status"`
+		deploymentPayload
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on new statuses
+	if event.Action != "created" {
+		return nil
+	}
+
+	// If branch filter is specified and doesn't match the deployed ref (a branch, tag or commit SHA), skip this event
+	if !branchFilter.Match(event.Deployment.Ref) {
+		return nil
+	}
+
+	d := event.toDeployment()
+	d.EnvironmentURL = event.DeploymentStatus.EnvironmentURL
+	if event.DeploymentStatus.LogURL != "" {
+		d.HTMLURL = event.DeploymentStatus.LogURL
+	} else if event.DeploymentStatus.TargetURL != "" {
+		d.HTMLURL = event.DeploymentStatus.TargetURL
+	}
+
+	return s.telegramSvc.UpdatePipelineMessage(chatID, d.URL, func(pipeline *storage.Pipeline) string {
+		// Statuses are not delivered in order, so a late in_progress status must not reopen a finished deployment
+		if deploymentStatusRank(event.DeploymentStatus.State) >= deploymentStatusRank(pipeline.Status) {
+			pipeline.Status = event.DeploymentStatus.State
+		}
+		return formatDeploymentMessage(d, pipeline, includeProject)
+	})
+}
+
+// deploymentStatusRank orders deployment statuses: a deployment goes through pending statuses,
+// finishes, and becomes inactive when a later deployment to the same environment succeeds
+func deploymentStatusRank(status string) int {
+	switch status {
+	case "success", "failure", "error":
+		return 1
+	case "inactive":
+		return 2
+	default:
+		return 0
+	}
+}
+
+// formatDeploymentMessage renders a deployment message with its last known status
+func formatDeploymentMessage(d deployment, pipeline *storage.Pipeline, includeProject bool) string {
+	var message strings.Builder
+
+	// Add emoji based on status
+	var emoji string
+	switch pipeline.Status {
+	case "success":
+		emoji = "✅"
+	case "failure", "error":
+		emoji = "❌"
+	case "in_progress":
+		emoji = "🔄"
+	case "queued", "pending":
+		emoji = "⏳"
+	case "inactive":
+		emoji = "💤"
+	case "created":
+		emoji = "🚀"
+	default:
+		emoji = "ℹ️"
+	}
+
+	message.WriteString(emoji + " ")
+	if includeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(d.Repository)))
+	}
+
+	// Link the environment once it's deployed
+	environment := fmt.Sprintf("<b>%s</b>", html.EscapeString(d.Environment))
+	if d.EnvironmentURL != "" {
+		environment = fmt.Sprintf("<a href=\"%s\">%s</a>", d.EnvironmentURL, environment)
+	}
+
+	// Replace underscores with spaces in the status
+	statusDisplay := strings.ReplaceAll(pipeline.Status, "_", " ")
+
+	message.WriteString(fmt.Sprintf(
+		"<a href=\"%s\">Deployment #%d</a> of <code>%s</code> to %s by <b>%s</b>: %s.",
+		d.HTMLURL,
+		d.ID,
+		html.EscapeString(d.Ref),
+		environment,
+		html.EscapeString(d.Creator),
+		html.EscapeString(statusDisplay),
+	))
+
+	return message.String()
+}
@@ -1,8 +1,14 @@
 package github
 
 import (
+	"encoding/json"
+	"expvar"
 	"fmt"
+	"html"
+	"log"
+	"strings"
 
+	"git-telegram-bot/internal/filter"
 	telegram "git-telegram-bot/internal/services/telegram/github"
 )
 
@@ -16,15 +22,156 @@ func NewGitHubService(telegramSvc *telegram.GitHubTelegramService) *GitHubServic
 	}
 }
 
-func (s *GitHubService) HandleEvent(chatID int64, eventType string, payload []byte, branchFilter string, includeProject bool) error {
+// EventOptions are per-webhook options, set with webhook URL query parameters
+type EventOptions struct {
+	BranchFilter     *filter.BranchFilter // Only report events for matching branches
+	IncludeProject   bool                 // Prefix messages with the repository name
+	IncludeCommunity bool                 // Report community events (see communityEvents)
+	IncludeUnknown   bool                 // Report unsupported events with a generic message
+}
+
+// unhandledEvents counts unsupported events by type, exposed for diagnostics at /debug/vars
+var unhandledEvents = expvar.NewMap("github_unhandled_events")
+
+// communityEvents are only reported when opted in, as they would flood engineering chats
+var communityEvents = map[string]bool{
+	"discussion":         true,
+	"discussion_comment": true,
+	"star":               true,
+	"fork":               true,
+	"watch":              true,
+}
+
+// HandleEvent reports a webhook event to the chat.
+//
+// The branch filter is matched against these fields, depending on the event type:
+//   - push: the pushed branch
+//   - pull_request, pull_request_review, pull_request_review_comment: the base branch
+//   - create, delete: the branch or tag name
+//   - workflow_run, workflow_job: the head branch of the run (or the tag name for tag runs)
+//   - check_run, check_suite: the head branch of the check suite
+//   - deployment, deployment_status: the deployed ref
+//
+// Events of other types have no branch and are not filtered. Handlers of new event types
+// with a branch must apply the filter, and be listed here and in the README.
+func (s *GitHubService) HandleEvent(chatID int64, eventType string, payload []byte, opts EventOptions) error {
+	if communityEvents[eventType] && !opts.IncludeCommunity {
+		return nil
+	}
+
+	branchFilter := opts.BranchFilter
+	includeProject := opts.IncludeProject
+
 	switch eventType {
 	case "ping":
 		return s.handlePingEvent(chatID, payload, includeProject)
 	case "push":
 		return s.handlePushEvent(chatID, payload, branchFilter, includeProject)
 	case "workflow_run":
-		return s.handleWorkflowRunEvent(chatID, payload, includeProject)
+		return s.handleWorkflowRunEvent(chatID, payload, branchFilter, includeProject)
+	case "workflow_job":
+		return s.handleWorkflowJobEvent(chatID, payload, branchFilter, includeProject)
+	case "check_run":
+		return s.handleCheckRunEvent(chatID, payload, branchFilter, includeProject)
+	case "check_suite":
+		return s.handleCheckSuiteEvent(chatID, payload, branchFilter, includeProject)
+	case "deployment":
+		return s.handleDeploymentEvent(chatID, payload, branchFilter, includeProject)
+	case "deployment_status":
+		return s.handleDeploymentStatusEvent(chatID, payload, branchFilter, includeProject)
+	case "pull_request":
+		return s.handlePullRequestEvent(chatID, payload, branchFilter, includeProject)
+	case "pull_request_review":
+		return s.handlePullRequestReviewEvent(chatID, payload, branchFilter, includeProject)
+	case "pull_request_review_comment":
+		return s.handlePullRequestReviewCommentEvent(chatID, payload, branchFilter, includeProject)
+	case "issues":
+		return s.handleIssuesEvent(chatID, payload, includeProject)
+	case "issue_comment":
+		return s.handleIssueCommentEvent(chatID, payload, includeProject)
+	case "release":
+		return s.handleReleaseEvent(chatID, payload, includeProject)
+	case "create":
+		return s.handleCreateEvent(chatID, payload, branchFilter, includeProject)
+	case "delete":
+		return s.handleDeleteEvent(chatID, payload, branchFilter, includeProject)
+	case "dependabot_alert":
+		return s.handleDependabotAlertEvent(chatID, payload, includeProject)
+	case "code_scanning_alert":
+		return s.handleCodeScanningAlertEvent(chatID, payload, includeProject)
+	case "secret_scanning_alert":
+		return s.handleSecretScanningAlertEvent(chatID, payload, includeProject)
+	case "repository_vulnerability_alert":
+		return s.handleRepositoryVulnerabilityAlertEvent(chatID, payload, includeProject)
+	case "discussion":
+		return s.handleDiscussionEvent(chatID, payload, includeProject)
+	case "discussion_comment":
+		return s.handleDiscussionCommentEvent(chatID, payload, includeProject)
+	case "star", "watch":
+		return s.handleStarEvent(chatID, payload, includeProject)
+	case "fork":
+		return s.handleForkEvent(chatID, payload, includeProject)
 	default:
-		return fmt.Errorf("unsupported event type: %s", eventType)
+		// Acknowledge unsupported events, so GitHub doesn't mark the deliveries as failed
+		log.Printf("Unsupported GitHub event type: %s", eventType)
+		unhandledEvents.Add(eventType, 1)
+		if opts.IncludeUnknown {
+			return s.handleUnknownEvent(chatID, eventType, payload, includeProject)
+		}
+		return nil
+	}
+}
+
+// handleUnknownEvent reports an unsupported event with a generic message
+func (s *GitHubService) handleUnknownEvent(chatID int64, eventType string, payload []byte, includeProject bool) error {
+	var event struct {
+		Action     string `json:"action"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+		Organization struct {
+			Login string `json:"login"`
+		} `json:"organization"`
+		Sender struct {
+			Login string `json:"login"`
+		} `json:"sender"`
 	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Build message
+	var message strings.Builder
+
+	message.WriteString("ℹ️ ")
+	if includeProject && event.Repository.FullName != "" {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(event.Repository.FullName)))
+	}
+
+	eventName := eventType
+	if event.Action != "" {
+		eventName += "." + event.Action
+	}
+
+	message.WriteString(fmt.Sprintf(
+		"<b>%s</b> triggered <code>%s</code>",
+		html.EscapeString(event.Sender.Login),
+		html.EscapeString(eventName),
+	))
+
+	// Some events (e.g. organization events) don't belong to a repository
+	if event.Repository.FullName != "" {
+		message.WriteString(fmt.Sprintf(
+			" on <a href=\"%s\">%s</a>",
+			event.Repository.HTMLURL,
+			html.EscapeString(event.Repository.FullName),
+		))
+	} else if event.Organization.Login != "" {
+		message.WriteString(fmt.Sprintf(" on <b>%s</b>", html.EscapeString(event.Organization.Login)))
+	}
+	message.WriteString(".")
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
 }
@@ -0,0 +1,168 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"git-telegram-bot/internal/services/telegram"
+)
+
+func (s *GitHubService) handleIssuesEvent(chatID int64, payload []byte, includeProject bool) error {
+	var event struct {
+		Action string `json:"action"`
+		Issue  struct {
+			Number      int    `json:"number"`
+			Title       string `json:"title"`
+			HTMLURL     string `json:"html_url"`
+			StateReason string `json:"state_reason"`
+		} `json:"issue"`
+		Assignee *struct {
+			Login string `json:"login"`
+		} `json:"assignee"`
+		Label *struct {
+			Name string `json:"name"`
+		} `json:"label"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+		Sender struct {
+			Login string `json:"login"`
+		} `json:"sender"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on known issue actions
+	if event.Action != "opened" &&
+		event.Action != "closed" &&
+		event.Action != "reopened" &&
+		event.Action != "assigned" &&
+		event.Action != "labeled" {
+		return nil
+	}
+
+	// Build message
+	var message strings.Builder
+
+	// Add emoji based on action
+	var emoji string
+	var action string
+	var actionSuffix string
+	switch event.Action {
+	case "opened":
+		emoji = "🆕"
+		action = "opened"
+	case "closed":
+		if event.Issue.StateReason == "not_planned" {
+			emoji = "🚫"
+			action = "closed"
+			actionSuffix = " as not planned"
+		} else {
+			emoji = "✅"
+			action = "closed"
+		}
+	case "reopened":
+		emoji = "🔄"
+		action = "reopened"
+	case "assigned":
+		emoji = "👤"
+		if event.Assignee == nil || event.Assignee.Login == event.Sender.Login {
+			action = "self-assigned"
+		} else {
+			action = fmt.Sprintf("assigned <b>%s</b> to", html.EscapeString(event.Assignee.Login))
+		}
+	case "labeled":
+		emoji = "🏷️"
+		action = "labeled"
+		if event.Label != nil {
+			actionSuffix = fmt.Sprintf(" with <code>%s</code>", html.EscapeString(event.Label.Name))
+		}
+	default:
+		emoji = "ℹ️"
+		action = event.Action
+	}
+
+	message.WriteString(emoji + " ")
+	if includeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(event.Repository.FullName)))
+	}
+
+	message.WriteString(fmt.Sprintf(
+		"<b>%s</b> %s <a href=\"%s\">#%d %s</a>%s.",
+		html.EscapeString(event.Sender.Login),
+		action,
+		event.Issue.HTMLURL,
+		event.Issue.Number,
+		html.EscapeString(event.Issue.Title),
+		actionSuffix,
+	))
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
+
+func (s *GitHubService) handleIssueCommentEvent(chatID int64, payload []byte, includeProject bool) error {
+	var event struct {
+		Action string `json:"action"`
+		Issue  struct {
+			Number      int    `json:"number"`
+			Title       string `json:"title"`
+			PullRequest *struct {
+				HTMLURL string `json:"html_url"`
+			} `json:"pull_request"`
+		} `json:"issue"`
+		Comment struct {
+			Body    string `json:"body"`
+			HTMLURL string `json:"html_url"`
+		} `json:"comment"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+		Sender struct {
+			Login string `json:"login"`
+		} `json:"sender"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on new comments
+	if event.Action != "created" {
+		return nil
+	}
+
+	// GitHub treats pull requests as issues; they're told apart by the pull_request field
+	target := "issue"
+	if event.Issue.PullRequest != nil {
+		target = "pull request"
+	}
+
+	// Build message
+	var message strings.Builder
+
+	message.WriteString("💬 ")
+	if includeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(event.Repository.FullName)))
+	}
+
+	message.WriteString(fmt.Sprintf(
+		"<b>%s</b> commented on %s <a href=\"%s\">#%d %s</a>.",
+		html.EscapeString(event.Sender.Login),
+		target,
+		event.Comment.HTMLURL,
+		event.Issue.Number,
+		html.EscapeString(event.Issue.Title),
+	))
+
+	if excerpt := telegram.FormatExcerpt(event.Comment.Body); excerpt != "" {
+		message.WriteString("\n" + excerpt)
+	}
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
@@ -0,0 +1,113 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"git-telegram-bot/internal/filter"
+)
+
+func (s *GitHubService) handlePullRequestEvent(chatID int64, payload []byte, branchFilter *filter.BranchFilter, includeProject bool) error {
+	var event struct {
+		Action      string `json:"action"`
+		PullRequest struct {
+			Number  int    `json:"number"`
+			Title   string `json:"title"`
+			HTMLURL string `json:"html_url"`
+			Draft   bool   `json:"draft"`
+			Merged  bool   `json:"merged"`
+			Head    struct {
+				Ref string `json:"ref"`
+			} `json:"head"`
+			Base struct {
+				Ref string `json:"ref"`
+			} `json:"base"`
+		} `json:"pull_request"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+		Sender struct {
+			Login string `json:"login"`
+		} `json:"sender"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on known PR actions
+	if event.Action != "opened" &&
+		event.Action != "closed" &&
+		event.Action != "reopened" &&
+		event.Action != "ready_for_review" &&
+		event.Action != "converted_to_draft" {
+		return nil
+	}
+
+	// If branch filter is specified and doesn't match the base branch, skip this event
+	if !branchFilter.Match(event.PullRequest.Base.Ref) {
+		return nil
+	}
+
+	// Build message
+	var message strings.Builder
+
+	// Add emoji based on action
+	var emoji string
+	var action string
+	var actionSuffix string
+	switch event.Action {
+	case "opened":
+		emoji = "🔀"
+		action = "opened"
+	case "closed":
+		if event.PullRequest.Merged {
+			emoji = "✅"
+			action = "merged"
+		} else {
+			emoji = "❌"
+			action = "closed"
+		}
+	case "reopened":
+		emoji = "🔀"
+		action = "reopened"
+	case "ready_for_review":
+		emoji = "👀"
+		action = "marked"
+		actionSuffix = " as ready for review"
+	case "converted_to_draft":
+		emoji = "📝"
+		action = "converted"
+		actionSuffix = " to draft"
+	default:
+		emoji = "ℹ️"
+		action = event.Action
+	}
+
+	// Mention draft state unless the action itself is about it
+	if event.PullRequest.Draft && actionSuffix == "" {
+		action += " draft"
+	}
+
+	message.WriteString(emoji + " ")
+	if includeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(event.Repository.FullName)))
+	}
+
+	message.WriteString(fmt.Sprintf(
+		"<b>%s</b> %s <a href=\"%s\">#%d %s</a>%s (<code>%s</code> → <code>%s</code>).",
+		html.EscapeString(event.Sender.Login),
+		action,
+		event.PullRequest.HTMLURL,
+		event.PullRequest.Number,
+		html.EscapeString(event.PullRequest.Title),
+		actionSuffix,
+		html.EscapeString(event.PullRequest.Head.Ref),
+		html.EscapeString(event.PullRequest.Base.Ref),
+	))
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
@@ -0,0 +1,171 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"git-telegram-bot/internal/filter"
+	"git-telegram-bot/internal/services/telegram"
+)
+
+func (s *GitHubService) handlePullRequestReviewEvent(chatID int64, payload []byte, branchFilter *filter.BranchFilter, includeProject bool) error {
+	var event struct {
+		Action string `json:"action"`
+		Review struct {
+			Body    string `json:"body"`
+			State   string `json:"state"`
+			HTMLURL string `json:"html_url"`
+		} `json:"review"`
+		PullRequest struct {
+			Number int    `json:"number"`
+			Title  string `json:"title"`
+			Head   struct {
+				Ref string `json:"ref"`
+			} `json:"head"`
+			Base struct {
+				Ref string `json:"ref"`
+			} `json:"base"`
+		} `json:"pull_request"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+		Sender struct {
+			Login string `json:"login"`
+		} `json:"sender"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on submitted and dismissed reviews
+	if event.Action != "submitted" && event.Action != "dismissed" {
+		return nil
+	}
+
+	// Reviews with only line comments are reported as pull_request_review_comment events
+	if event.Action == "submitted" && event.Review.State == "commented" && strings.TrimSpace(event.Review.Body) == "" {
+		return nil
+	}
+
+	// If branch filter is specified and doesn't match the base branch, skip this event
+	if !branchFilter.Match(event.PullRequest.Base.Ref) {
+		return nil
+	}
+
+	// Build message
+	var message strings.Builder
+
+	// Add emoji based on review state
+	var emoji string
+	var action string
+	if event.Action == "dismissed" {
+		emoji = "🚫"
+		action = "dismissed a review of"
+	} else {
+		switch event.Review.State {
+		case "approved":
+			emoji = "✅"
+			action = "approved"
+		case "changes_requested":
+			emoji = "❌"
+			action = "requested changes to"
+		case "commented":
+			emoji = "💬"
+			action = "reviewed"
+		default:
+			emoji = "ℹ️"
+			action = "reviewed"
+		}
+	}
+
+	message.WriteString(emoji + " ")
+	if includeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(event.Repository.FullName)))
+	}
+
+	message.WriteString(fmt.Sprintf(
+		"<b>%s</b> %s <a href=\"%s\">#%d %s</a> (<code>%s</code> → <code>%s</code>).",
+		html.EscapeString(event.Sender.Login),
+		action,
+		event.Review.HTMLURL,
+		event.PullRequest.Number,
+		html.EscapeString(event.PullRequest.Title),
+		html.EscapeString(event.PullRequest.Head.Ref),
+		html.EscapeString(event.PullRequest.Base.Ref),
+	))
+
+	// Dismissal messages are written by the dismisser, not the reviewer, and aren't in the payload
+	if event.Action == "submitted" {
+		if excerpt := telegram.FormatExcerpt(event.Review.Body); excerpt != "" {
+			message.WriteString("\n" + excerpt)
+		}
+	}
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
+
+func (s *GitHubService) handlePullRequestReviewCommentEvent(chatID int64, payload []byte, branchFilter *filter.BranchFilter, includeProject bool) error {
+	var event struct {
+		Action  string `json:"action"`
+		Comment struct {
+			Body    string `json:"body"`
+			Path    string `json:"path"`
+			HTMLURL string `json:"html_url"`
+		} `json:"comment"`
+		PullRequest struct {
+			Number int    `json:"number"`
+			Title  string `json:"title"`
+			Base   struct {
+				Ref string `json:"ref"`
+			} `json:"base"`
+		} `json:"pull_request"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+		Sender struct {
+			Login string `json:"login"`
+		} `json:"sender"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on new comments
+	if event.Action != "created" {
+		return nil
+	}
+
+	// If branch filter is specified and doesn't match the base branch, skip this event
+	if !branchFilter.Match(event.PullRequest.Base.Ref) {
+		return nil
+	}
+
+	// Build message
+	var message strings.Builder
+
+	message.WriteString("💬 ")
+	if includeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(event.Repository.FullName)))
+	}
+
+	message.WriteString(fmt.Sprintf(
+		"<b>%s</b> commented on <code>%s</code> in <a href=\"%s\">#%d %s</a>.",
+		html.EscapeString(event.Sender.Login),
+		html.EscapeString(event.Comment.Path),
+		event.Comment.HTMLURL,
+		event.PullRequest.Number,
+		html.EscapeString(event.PullRequest.Title),
+	))
+
+	if excerpt := telegram.FormatExcerpt(event.Comment.Body); excerpt != "" {
+		message.WriteString("\n" + excerpt)
+	}
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
@@ -6,10 +6,11 @@ import (
 	"html"
 	"strings"
 
+	"git-telegram-bot/internal/filter"
 	"git-telegram-bot/internal/services/telegram"
 )
 
-func (s *GitHubService) handlePushEvent(chatID int64, payload []byte, branchFilter string, includeProject bool) error {
+func (s *GitHubService) handlePushEvent(chatID int64, payload []byte, branchFilter *filter.BranchFilter, includeProject bool) error {
 	var event struct {
 		Ref        string `json:"ref"`
 		Before     string `json:"before"`
@@ -42,7 +43,7 @@ func (s *GitHubService) handlePushEvent(chatID int64, payload []byte, branchFilt
 	branch := strings.TrimPrefix(event.Ref, "refs/heads/")
 
 	// If branch filter is specified and doesn't match the current branch, skip this event
-	if branchFilter != "" && branchFilter != branch {
+	if !branchFilter.Match(branch) {
 		return nil
 	}
 
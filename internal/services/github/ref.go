@@ -0,0 +1,97 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"git-telegram-bot/internal/filter"
+)
+
+func (s *GitHubService) handleCreateEvent(chatID int64, payload []byte, branchFilter *filter.BranchFilter, includeProject bool) error {
+	return s.handleRefEvent(chatID, payload, branchFilter, includeProject, true)
+}
+
+func (s *GitHubService) handleDeleteEvent(chatID int64, payload []byte, branchFilter *filter.BranchFilter, includeProject bool) error {
+	return s.handleRefEvent(chatID, payload, branchFilter, includeProject, false)
+}
+
+// handleRefEvent handles tag and branch creation ("create" event) and deletion ("delete" event)
+func (s *GitHubService) handleRefEvent(chatID int64, payload []byte, branchFilter *filter.BranchFilter, includeProject bool, created bool) error {
+	var event struct {
+		Ref        string `json:"ref"`
+		RefType    string `json:"ref_type"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+		Sender struct {
+			Login string `json:"login"`
+		} `json:"sender"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on tags and branches
+	if event.RefType != "tag" && event.RefType != "branch" {
+		return nil
+	}
+
+	// Branch deletions are reported by the push event, which GitHub sends along with the delete event
+	if !created && event.RefType == "branch" {
+		return nil
+	}
+
+	// Tags have no branch, so the branch filter is matched against the tag name for tags
+	if !branchFilter.Match(event.Ref) {
+		return nil
+	}
+
+	// Build message
+	var message strings.Builder
+
+	// Add emoji based on event type
+	var emoji string
+	var action string
+	switch {
+	case !created:
+		emoji = "🗑️"
+		action = "deleted"
+	case event.RefType == "tag":
+		emoji = "🏷️"
+		action = "created"
+	default:
+		emoji = "🌱"
+		action = "created"
+	}
+
+	message.WriteString(emoji + " ")
+	if includeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(event.Repository.FullName)))
+	}
+
+	if created {
+		message.WriteString(fmt.Sprintf(
+			"<b>%s</b> %s %s <a href=\"%s/tree/%s\"><code>%s</code></a>.",
+			html.EscapeString(event.Sender.Login),
+			action,
+			event.RefType,
+			event.Repository.HTMLURL,
+			html.EscapeString(event.Ref),
+			html.EscapeString(event.Ref),
+		))
+	} else {
+		message.WriteString(fmt.Sprintf(
+			"<b>%s</b> %s %s <code>%s</code>.",
+			html.EscapeString(event.Sender.Login),
+			action,
+			event.RefType,
+			html.EscapeString(event.Ref),
+		))
+	}
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
@@ -0,0 +1,101 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"git-telegram-bot/internal/services/telegram"
+)
+
+func (s *GitHubService) handleReleaseEvent(chatID int64, payload []byte, includeProject bool) error {
+	var event struct {
+		Action  string `json:"action"`
+		Release struct {
+			Name       string `json:"name"`
+			TagName    string `json:"tag_name"`
+			HTMLURL    string `json:"html_url"`
+			Body       string `json:"body"`
+			Prerelease bool   `json:"prerelease"`
+			Author     struct {
+				Login string `json:"login"`
+			} `json:"author"`
+		} `json:"release"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+		Sender struct {
+			Login string `json:"login"`
+		} `json:"sender"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on known release actions
+	if event.Action != "published" &&
+		event.Action != "prereleased" &&
+		event.Action != "edited" {
+		return nil
+	}
+
+	// Pre-releases trigger both "published" and "prereleased", only report the latter
+	if event.Action == "published" && event.Release.Prerelease {
+		return nil
+	}
+
+	// Build message
+	var message strings.Builder
+
+	// Add emoji based on action
+	var emoji string
+	var action string
+	user := event.Release.Author.Login
+	switch event.Action {
+	case "published":
+		emoji = "🎉"
+		action = "published release"
+	case "prereleased":
+		emoji = "🧪"
+		action = "published pre-release"
+	case "edited":
+		emoji = "✏️"
+		action = "edited release"
+		user = event.Sender.Login
+	default:
+		emoji = "ℹ️"
+		action = event.Action
+	}
+
+	// Releases don't have to be named, fall back to the tag
+	name := event.Release.Name
+	if name == "" {
+		name = event.Release.TagName
+	}
+
+	message.WriteString(emoji + " ")
+	if includeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(event.Repository.FullName)))
+	}
+
+	message.WriteString(fmt.Sprintf(
+		"<b>%s</b> %s <a href=\"%s\">%s</a> (<code>%s</code>).",
+		html.EscapeString(user),
+		action,
+		event.Release.HTMLURL,
+		html.EscapeString(name),
+		html.EscapeString(event.Release.TagName),
+	))
+
+	// Only include release notes when the release is announced
+	if event.Action != "edited" {
+		if excerpt := telegram.FormatExcerpt(event.Release.Body); excerpt != "" {
+			message.WriteString("\n" + excerpt)
+		}
+	}
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
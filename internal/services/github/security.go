@@ -0,0 +1,235 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// securityAlert is what's shown for Dependabot, code scanning and secret scanning alerts
+type securityAlert struct {
+	Kind     string // e.g. "Dependabot alert"
+	Number   int
+	HTMLURL  string
+	Severity string
+	Subject  string // Package, rule or secret type, never the secret itself
+	Summary  string
+}
+
+// securityAlertActions maps alert actions (of all alert event types) to what's shown in the message.
+// Actions that aren't listed are not reported.
+var securityAlertActions = map[string]struct {
+	Action   string
+	Resolved bool
+}{
+	"create":             {"opened", false},
+	"created":            {"opened", false},
+	"reopen":             {"reopened", false},
+	"reopened":           {"reopened", false},
+	"reopened_by_user":   {"reopened", false},
+	"reintroduced":       {"reintroduced", false},
+	"auto_reopened":      {"reopened", false},
+	"publicly_leaked":    {"found publicly leaked", false},
+	"fixed":              {"fixed", true},
+	"resolve":            {"resolved", true},
+	"resolved":           {"resolved", true},
+	"revoked":            {"revoked", true},
+	"dismiss":            {"dismissed", true},
+	"dismissed":          {"dismissed", true},
+	"auto_dismissed":     {"dismissed", true},
+	"closed_by_user":     {"dismissed", true},
+	"appeared_in_branch": {"appeared in a new branch", false},
+}
+
+func (s *GitHubService) handleDependabotAlertEvent(chatID int64, payload []byte, includeProject bool) error {
+	var event struct {
+		Action string `json:"action"`
+		Alert  struct {
+			Number           int    `json:"number"`
+			HTMLURL          string `json:"html_url"`
+			SecurityAdvisory struct {
+				Summary  string `json:"summary"`
+				Severity string `json:"severity"`
+			} `json:"security_advisory"`
+			Dependency struct {
+				Package struct {
+					Name      string `json:"name"`
+					Ecosystem string `json:"ecosystem"`
+				} `json:"package"`
+			} `json:"dependency"`
+		} `json:"alert"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	pkg := event.Alert.Dependency.Package
+	return s.sendSecurityAlert(chatID, event.Repository.FullName, event.Action, securityAlert{
+		Kind:     "Dependabot alert",
+		Number:   event.Alert.Number,
+		HTMLURL:  event.Alert.HTMLURL,
+		Severity: event.Alert.SecurityAdvisory.Severity,
+		Subject:  fmt.Sprintf("%s (%s)", pkg.Name, pkg.Ecosystem),
+		Summary:  event.Alert.SecurityAdvisory.Summary,
+	}, includeProject)
+}
+
+func (s *GitHubService) handleCodeScanningAlertEvent(chatID int64, payload []byte, includeProject bool) error {
+	var event struct {
+		Action string `json:"action"`
+		Alert  struct {
+			Number  int    `json:"number"`
+			HTMLURL string `json:"html_url"`
+			Rule    struct {
+				ID                    string `json:"id"`
+				Severity              string `json:"severity"`
+				SecuritySeverityLevel string `json:"security_severity_level"`
+				Description           string `json:"description"`
+			} `json:"rule"`
+			Tool struct {
+				Name string `json:"name"`
+			} `json:"tool"`
+		} `json:"alert"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Security rules have a security severity, other rules only have error/warning/note
+	severity := event.Alert.Rule.SecuritySeverityLevel
+	if severity == "" {
+		severity = event.Alert.Rule.Severity
+	}
+
+	return s.sendSecurityAlert(chatID, event.Repository.FullName, event.Action, securityAlert{
+		Kind:     event.Alert.Tool.Name + " alert",
+		Number:   event.Alert.Number,
+		HTMLURL:  event.Alert.HTMLURL,
+		Severity: severity,
+		Subject:  event.Alert.Rule.ID,
+		Summary:  event.Alert.Rule.Description,
+	}, includeProject)
+}
+
+func (s *GitHubService) handleSecretScanningAlertEvent(chatID int64, payload []byte, includeProject bool) error {
+	// The payload contains the secret itself, which is deliberately not parsed
+	var event struct {
+		Action string `json:"action"`
+		Alert  struct {
+			Number                int    `json:"number"`
+			HTMLURL               string `json:"html_url"`
+			SecretTypeDisplayName string `json:"secret_type_display_name"`
+		} `json:"alert"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Leaked secrets are always critical
+	return s.sendSecurityAlert(chatID, event.Repository.FullName, event.Action, securityAlert{
+		Kind:     "Secret scanning alert",
+		Number:   event.Alert.Number,
+		HTMLURL:  event.Alert.HTMLURL,
+		Severity: "critical",
+		Subject:  event.Alert.SecretTypeDisplayName,
+	}, includeProject)
+}
+
+// handleRepositoryVulnerabilityAlertEvent handles the legacy predecessor of dependabot_alert events
+func (s *GitHubService) handleRepositoryVulnerabilityAlertEvent(chatID int64, payload []byte, includeProject bool) error {
+	var event struct {
+		Action string `json:"action"`
+		Alert  struct {
+			Number              int    `json:"number"`
+			AffectedPackageName string `json:"affected_package_name"`
+			Severity            string `json:"severity"`
+			ExternalIdentifier  string `json:"external_identifier"`
+		} `json:"alert"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	return s.sendSecurityAlert(chatID, event.Repository.FullName, event.Action, securityAlert{
+		Kind:     "Vulnerability alert",
+		Number:   event.Alert.Number,
+		HTMLURL:  event.Repository.HTMLURL + "/security/dependabot",
+		Severity: event.Alert.Severity,
+		Subject:  event.Alert.AffectedPackageName,
+		Summary:  event.Alert.ExternalIdentifier,
+	}, includeProject)
+}
+
+// sendSecurityAlert sends the message for an alert event, if the action is worth reporting
+func (s *GitHubService) sendSecurityAlert(chatID int64, repository string, action string, alert securityAlert, includeProject bool) error {
+	alertAction, ok := securityAlertActions[action]
+	if !ok {
+		return nil
+	}
+
+	// Build message
+	var message strings.Builder
+
+	// Add emoji based on severity, unless the alert is gone
+	var emoji string
+	if alertAction.Resolved {
+		emoji = "✅"
+	} else {
+		switch strings.ToLower(alert.Severity) {
+		case "critical":
+			emoji = "🚨"
+		case "high", "error":
+			emoji = "🔴"
+		case "medium", "moderate", "warning":
+			emoji = "🟠"
+		case "low", "note":
+			emoji = "🟡"
+		default:
+			emoji = "ℹ️"
+		}
+	}
+
+	message.WriteString(emoji + " ")
+	if includeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(repository)))
+	}
+
+	message.WriteString(fmt.Sprintf(
+		"<a href=\"%s\">%s #%d</a> %s",
+		alert.HTMLURL,
+		html.EscapeString(alert.Kind),
+		alert.Number,
+		alertAction.Action,
+	))
+	if alert.Severity != "" {
+		message.WriteString(fmt.Sprintf(" (<b>%s</b>)", html.EscapeString(alert.Severity)))
+	}
+	message.WriteString(fmt.Sprintf(": <code>%s</code>", html.EscapeString(alert.Subject)))
+	if alert.Summary != "" {
+		message.WriteString(" — " + html.EscapeString(alert.Summary))
+	}
+	message.WriteString(".")
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
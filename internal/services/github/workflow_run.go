@@ -5,14 +5,29 @@ import (
 	"fmt"
 	"html"
 	"strings"
+	"time"
+
+	"git-telegram-bot/internal/filter"
+	"git-telegram-bot/internal/services/telegram"
+	"git-telegram-bot/internal/storage"
 )
 
-func (s *GitHubService) handleWorkflowRunEvent(chatID int64, payload []byte, includeProject bool) error {
+// workflowRun identifies a workflow run message, and can be built from both workflow_run and workflow_job events
+type workflowRun struct {
+	Name       string
+	HTMLURL    string
+	HeadBranch string
+	Repository string
+}
+
+func (s *GitHubService) handleWorkflowRunEvent(chatID int64, payload []byte, branchFilter *filter.BranchFilter, includeProject bool) error {
 	var event struct {
 		Action      string `json:"action"`
 		WorkflowRun struct {
 			Name       string `json:"name"`
 			HTMLURL    string `json:"html_url"`
+			RunAttempt int    `json:"run_attempt"`
+			HeadBranch string `json:"head_branch"`
 			Status     string `json:"status"`
 			Conclusion string `json:"conclusion"`
 		} `json:"workflow_run"`
@@ -26,38 +41,208 @@ func (s *GitHubService) handleWorkflowRunEvent(chatID int64, payload []byte, inc
 		return err
 	}
 
-	// Only notify on completed workflow runs
-	if event.Action != "completed" {
+	// Only notify on known workflow run actions
+	if event.Action != "requested" &&
+		event.Action != "in_progress" &&
+		event.Action != "completed" {
 		return nil
 	}
 
-	// Build message
-	var message strings.Builder
+	// If branch filter is specified and doesn't match the run's branch, skip this event
+	if !branchFilter.Match(event.WorkflowRun.HeadBranch) {
+		return nil
+	}
 
-	// Add emoji based on conclusion
-	var emoji string
-	switch event.WorkflowRun.Conclusion {
-	case "success":
-		emoji = "✅"
-	case "failure":
-		emoji = "❌"
-	case "cancelled":
-		emoji = "⚠️"
+	run := workflowRun{
+		Name:       event.WorkflowRun.Name,
+		HTMLURL:    workflowRunAttemptURL(event.WorkflowRun.HTMLURL, event.WorkflowRun.RunAttempt),
+		HeadBranch: event.WorkflowRun.HeadBranch,
+		Repository: event.Repository.FullName,
+	}
+
+	// Completed runs are described by their conclusion, others by their status
+	status := event.WorkflowRun.Status
+	if status == "completed" {
+		status = event.WorkflowRun.Conclusion
+	}
+
+	// Edit the run's existing message as it progresses, or create a new one
+	return s.telegramSvc.UpdatePipelineMessage(chatID, run.HTMLURL, func(pipeline *storage.Pipeline) string {
+		// Events are not delivered in order, so a late in_progress event must not reopen a completed run
+		if !isWorkflowConclusion(pipeline.Status) || isWorkflowConclusion(status) {
+			pipeline.Status = status
+		}
+		return formatWorkflowRunMessage(run, pipeline, includeProject)
+	})
+}
+
+func (s *GitHubService) handleWorkflowJobEvent(chatID int64, payload []byte, branchFilter *filter.BranchFilter, includeProject bool) error {
+	var event struct {
+		Action      string `json:"action"`
+		WorkflowJob struct {
+			ID           int64     `json:"id"`
+			RunID        int64     `json:"run_id"`
+			RunAttempt   int       `json:"run_attempt"`
+			Name         string    `json:"name"`
+			WorkflowName string    `json:"workflow_name"`
+			HeadBranch   string    `json:"head_branch"`
+			Status       string    `json:"status"`
+			Conclusion   string    `json:"conclusion"`
+			StartedAt    time.Time `json:"started_at"`
+			CompletedAt  time.Time `json:"completed_at"`
+			RunnerName   string    `json:"runner_name"`
+		} `json:"workflow_job"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on known workflow job actions
+	if event.Action != "queued" &&
+		event.Action != "waiting" &&
+		event.Action != "in_progress" &&
+		event.Action != "completed" {
+		return nil
+	}
+
+	job := event.WorkflowJob
+
+	// If branch filter is specified and doesn't match the run's branch, skip this event
+	if !branchFilter.Match(job.HeadBranch) {
+		return nil
+	}
+
+	// Jobs are grouped into the message of their parent run, which is keyed by the run attempt URL
+	run := workflowRun{
+		Name:       job.WorkflowName,
+		HTMLURL:    workflowRunAttemptURL(fmt.Sprintf("%s/actions/runs/%d", event.Repository.HTMLURL, job.RunID), job.RunAttempt),
+		HeadBranch: job.HeadBranch,
+		Repository: event.Repository.FullName,
+	}
+
+	// Completed jobs are described by their conclusion, others by their status
+	status := job.Status
+	var duration float64
+	if status == "completed" {
+		status = job.Conclusion
+		if !job.StartedAt.IsZero() && job.CompletedAt.After(job.StartedAt) {
+			duration = job.CompletedAt.Sub(job.StartedAt).Seconds()
+		}
+	}
+
+	return s.telegramSvc.UpdatePipelineMessage(chatID, run.HTMLURL, func(pipeline *storage.Pipeline) string {
+		// The job may be reported before its run
+		if pipeline.Status == "" {
+			pipeline.Status = "in_progress"
+		}
+		// A late queued or in_progress event must not replace the job's conclusion and duration
+		if stored, ok := pipeline.Job(job.ID); !ok || !isWorkflowConclusion(stored.Status) || isWorkflowConclusion(status) {
+			pipeline.SetJob(storage.PipelineJob{
+				ID:       job.ID,
+				Name:     job.Name,
+				Status:   status,
+				Duration: duration,
+				Runner:   job.RunnerName,
+			})
+		}
+		return formatWorkflowRunMessage(run, pipeline, includeProject)
+	})
+}
+
+// workflowRunAttemptURL returns the URL of a run attempt, which keys its message,
+// so that re-runs get a new message instead of reopening the completed one
+func workflowRunAttemptURL(runURL string, attempt int) string {
+	if attempt > 1 {
+		return fmt.Sprintf("%s/attempts/%d", runURL, attempt)
+	}
+	return runURL
+}
+
+// isWorkflowConclusion reports whether a workflow run or job status is the conclusion of a completed one
+func isWorkflowConclusion(status string) bool {
+	switch status {
+	case "", "requested", "queued", "waiting", "pending", "in_progress":
+		return false
 	default:
-		emoji = "ℹ️"
+		return true
 	}
+}
 
-	message.WriteString(emoji + " ")
+// formatWorkflowRunMessage renders a workflow run message with the run's jobs known so far
+func formatWorkflowRunMessage(run workflowRun, pipeline *storage.Pipeline, includeProject bool) string {
+	var message strings.Builder
+
+	message.WriteString(workflowStatusEmoji(pipeline.Status) + " ")
 	if includeProject {
-		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(event.Repository.FullName)))
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(run.Repository)))
 	}
 
+	// Replace underscores with spaces in the status
+	statusDisplay := strings.ReplaceAll(pipeline.Status, "_", " ")
+
 	message.WriteString(fmt.Sprintf(
-		"<a href=\"%s\">%s</a> %s.",
-		event.WorkflowRun.HTMLURL,
-		html.EscapeString(event.WorkflowRun.Name),
-		html.EscapeString(event.WorkflowRun.Conclusion),
+		"<a href=\"%s\">%s</a> %s for <code>%s</code>",
+		run.HTMLURL,
+		html.EscapeString(run.Name),
+		html.EscapeString(statusDisplay),
+		html.EscapeString(run.HeadBranch),
 	))
 
-	return s.telegramSvc.SendMessage(chatID, message.String())
+	if len(pipeline.Jobs) == 0 {
+		message.WriteString(".")
+		return message.String()
+	}
+
+	// Add job information
+	message.WriteString(":\n")
+	for _, job := range pipeline.Jobs {
+		var details []string
+		if duration := telegram.FormatDuration(job.Duration); duration != "" {
+			details = append(details, duration)
+		}
+		if job.Runner != "" {
+			details = append(details, html.EscapeString(job.Runner))
+		}
+
+		message.WriteString(fmt.Sprintf(
+			"%s <b>%s</b>",
+			workflowStatusEmoji(job.Status),
+			html.EscapeString(job.Name),
+		))
+		if len(details) > 0 {
+			message.WriteString(" (" + strings.Join(details, ", ") + ")")
+		}
+		message.WriteString("\n")
+	}
+
+	return message.String()
+}
+
+// workflowStatusEmoji returns the emoji for a workflow run or job status (or conclusion, if completed)
+func workflowStatusEmoji(status string) string {
+	switch status {
+	case "success":
+		return "✅"
+	case "failure", "timed_out":
+		return "❌"
+	case "cancelled":
+		return "⚠️"
+	case "skipped", "neutral":
+		return "⏭️"
+	case "action_required":
+		return "✋"
+	case "in_progress":
+		return "🔄"
+	case "queued", "requested", "pending":
+		return "⏳"
+	case "waiting":
+		return "🚦"
+	default:
+		return "ℹ️"
+	}
 }
@@ -0,0 +1,99 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"git-telegram-bot/internal/storage"
+)
+
+func (s *GitLabService) handleDeploymentEvent(chatID int64, payload []byte, opts EventOptions) error {
+	var event struct {
+		Status                 string `json:"status"`
+		DeploymentID           int    `json:"deployment_id"`
+		DeployableURL          string `json:"deployable_url"`
+		Environment            string `json:"environment"`
+		EnvironmentExternalURL string `json:"environment_external_url"`
+		Ref                    string `json:"ref"`
+		ShortSHA               string `json:"short_sha"`
+		CommitURL              string `json:"commit_url"`
+		Project                struct {
+			Name              string `json:"name"`
+			PathWithNamespace string `json:"path_with_namespace"`
+			WebURL            string `json:"web_url"`
+		} `json:"project"`
+		User struct {
+			Name string `json:"name"`
+		} `json:"user"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// If branch filter is specified and doesn't match the deployed ref (a branch or tag), skip this event
+	if !opts.BranchFilter.Match(event.Ref) {
+		return nil
+	}
+
+	// Edit the deployment's existing message as its status changes, or create a new one
+	deploymentURL := fmt.Sprintf("%s/-/deployments/%d", event.Project.WebURL, event.DeploymentID)
+	return s.telegramSvc.UpdatePipelineMessage(chatID, deploymentURL, func(pipeline *storage.Pipeline) string {
+		// Events are not delivered in order, so a late running event must not reopen a finished deployment
+		if !isDeploymentFinished(pipeline.Status) || isDeploymentFinished(event.Status) {
+			pipeline.Status = event.Status
+		}
+
+		var message strings.Builder
+
+		// Add emoji based on status
+		var emoji string
+		switch pipeline.Status {
+		case "success":
+			emoji = "✅"
+		case "failed":
+			emoji = "❌"
+		case "running":
+			emoji = "🔄"
+		case "canceled":
+			emoji = "⚠️"
+		case "created":
+			emoji = "🚀"
+		default:
+			emoji = "ℹ️"
+		}
+
+		message.WriteString(emoji + " ")
+		if opts.IncludeProject {
+			message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(opts.projectName(event.Project.Name, event.Project.PathWithNamespace))))
+		}
+
+		// Link the environment if it has an external URL
+		environment := fmt.Sprintf("<b>%s</b>", html.EscapeString(event.Environment))
+		if event.EnvironmentExternalURL != "" {
+			environment = fmt.Sprintf("<a href=\"%s\">%s</a>", event.EnvironmentExternalURL, environment)
+		}
+
+		// The deployment links to its deployable job
+		message.WriteString(fmt.Sprintf(
+			"<a href=\"%s\">Deployment #%d</a> of <code>%s</code> (<a href=\"%s\"><code>%s</code></a>) to %s by <b>%s</b>: %s.",
+			event.DeployableURL,
+			event.DeploymentID,
+			html.EscapeString(event.Ref),
+			event.CommitURL,
+			html.EscapeString(event.ShortSHA),
+			environment,
+			html.EscapeString(event.User.Name),
+			html.EscapeString(pipeline.Status),
+		))
+
+		return message.String()
+	})
+}
+
+// isDeploymentFinished reports whether a deployment status is final (retrying a deployment creates a new one)
+func isDeploymentFinished(status string) bool {
+	return status == "success" || status == "failed" || status == "canceled"
+}
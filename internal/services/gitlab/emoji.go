@@ -0,0 +1,88 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+)
+
+func (s *GitLabService) handleEmojiEvent(chatID int64, payload []byte, opts EventOptions) error {
+	var event struct {
+		EventType        string `json:"event_type"`
+		ObjectAttributes struct {
+			Name          string `json:"name"`
+			AwardableType string `json:"awardable_type"`
+		} `json:"object_attributes"`
+		Project struct {
+			Name              string `json:"name"`
+			PathWithNamespace string `json:"path_with_namespace"`
+			WebURL            string `json:"web_url"`
+		} `json:"project"`
+		User struct {
+			Name string `json:"name"`
+		} `json:"user"`
+		MergeRequest struct {
+			IID          int    `json:"iid"`
+			Title        string `json:"title"`
+			URL          string `json:"url"`
+			TargetBranch string `json:"target_branch"`
+		} `json:"merge_request"`
+		Issue struct {
+			IID   int    `json:"iid"`
+			Title string `json:"title"`
+			URL   string `json:"url"`
+		} `json:"issue"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on added reactions
+	if event.EventType != "award" {
+		return nil
+	}
+
+	// Only notify on reactions to merge requests and issues
+	var target string
+	switch event.ObjectAttributes.AwardableType {
+	case "MergeRequest":
+		// If branch filter is specified and doesn't match the target branch, skip this event
+		if !opts.BranchFilter.Match(event.MergeRequest.TargetBranch) {
+			return nil
+		}
+		target = fmt.Sprintf(
+			"<a href=\"%s\">!%d %s</a>",
+			event.MergeRequest.URL,
+			event.MergeRequest.IID,
+			html.EscapeString(event.MergeRequest.Title),
+		)
+	case "Issue":
+		target = fmt.Sprintf(
+			"<a href=\"%s\">#%d %s</a>",
+			event.Issue.URL,
+			event.Issue.IID,
+			html.EscapeString(event.Issue.Title),
+		)
+	default:
+		return nil
+	}
+
+	// Build message
+	var message strings.Builder
+
+	message.WriteString("😀 ")
+	if opts.IncludeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(opts.projectName(event.Project.Name, event.Project.PathWithNamespace))))
+	}
+
+	message.WriteString(fmt.Sprintf(
+		"<b>%s</b> reacted with <code>:%s:</code> to %s.",
+		html.EscapeString(event.User.Name),
+		html.EscapeString(event.ObjectAttributes.Name),
+		target,
+	))
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
@@ -0,0 +1,58 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+)
+
+func (s *GitLabService) handleFeatureFlagEvent(chatID int64, payload []byte, opts EventOptions) error {
+	var event struct {
+		ObjectAttributes struct {
+			Name   string `json:"name"`
+			Active bool   `json:"active"`
+		} `json:"object_attributes"`
+		Project struct {
+			Name              string `json:"name"`
+			PathWithNamespace string `json:"path_with_namespace"`
+			WebURL            string `json:"web_url"`
+		} `json:"project"`
+		User struct {
+			Name string `json:"name"`
+		} `json:"user"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Build message
+	var message strings.Builder
+
+	// Add emoji based on flag state
+	var emoji string
+	var state string
+	if event.ObjectAttributes.Active {
+		emoji = "🟢"
+		state = "on"
+	} else {
+		emoji = "⚪"
+		state = "off"
+	}
+
+	message.WriteString(emoji + " ")
+	if opts.IncludeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(opts.projectName(event.Project.Name, event.Project.PathWithNamespace))))
+	}
+
+	message.WriteString(fmt.Sprintf(
+		"<b>%s</b> turned <a href=\"%s/-/feature_flags\">feature flag</a> <code>%s</code> %s.",
+		html.EscapeString(event.User.Name),
+		event.Project.WebURL,
+		html.EscapeString(event.ObjectAttributes.Name),
+		state,
+	))
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
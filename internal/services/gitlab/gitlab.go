@@ -1,8 +1,14 @@
 package gitlab
 
 import (
+	"encoding/json"
+	"expvar"
 	"fmt"
+	"html"
+	"log"
+	"strings"
 
+	"git-telegram-bot/internal/filter"
 	telegram "git-telegram-bot/internal/services/telegram/gitlab"
 )
 
@@ -16,17 +22,191 @@ func NewGitLabService(telegramSvc *telegram.GitLabTelegramService) *GitLabServic
 	}
 }
 
-func (s *GitLabService) HandleEvent(chatID int64, eventType string, payload []byte, includeProject bool) error {
+// EventOptions are per-webhook options, set with webhook URL query parameters
+type EventOptions struct {
+	BranchFilter       *filter.BranchFilter // Only report events for matching branches
+	IncludeProject     bool                 // Prefix messages with the project name
+	IncludeProjectPath bool                 // Use the full project path (e.g. group/project) in the prefix instead of the name
+	IncludeUnknown     bool                 // Report unsupported events with a generic message
+	IncludeFailedJobs  bool                 // Alert on failed jobs with separate messages
+	IncludeEmoji       bool                 // Report reactions to merge requests and issues
+}
+
+// projectName returns the project name or its full path to prefix messages with
+func (opts EventOptions) projectName(name, pathWithNamespace string) string {
+	if opts.IncludeProjectPath && pathWithNamespace != "" {
+		return pathWithNamespace
+	}
+	return name
+}
+
+// unhandledEvents counts unsupported events by type, exposed for diagnostics at /debug/vars
+var unhandledEvents = expvar.NewMap("gitlab_unhandled_events")
+
+// systemHookEvents maps the object kinds and event names of system hook payloads
+// to the project hook events with the same payload format
+var systemHookEvents = map[string]string{
+	"push":                   "Push Hook",
+	"tag_push":               "Tag Push Hook",
+	"merge_request":          "Merge Request Hook",
+	"pipeline":               "Pipeline Hook",
+	"build":                  "Job Hook",
+	"issue":                  "Issue Hook",
+	"note":                   "Note Hook",
+	"release":                "Release Hook",
+	"deployment":             "Deployment Hook",
+	"feature_flag":           "Feature Flag Hook",
+	"wiki_page":              "Wiki Page Hook",
+	"emoji":                  "Emoji Hook",
+	"user_add_to_group":      "Member Hook",
+	"user_update_for_group":  "Member Hook",
+	"user_remove_from_group": "Member Hook",
+	"subgroup_create":        "Subgroup Hook",
+	"subgroup_destroy":       "Subgroup Hook",
+}
+
+// HandleEvent reports a webhook event to the chat.
+//
+// The branch filter is matched against these fields, depending on the event type:
+//   - Push Hook: the pushed branch
+//   - Tag Push Hook: the tag name
+//   - Merge Request Hook: the target branch
+//   - Pipeline Hook, Job Hook: the pipeline ref (the source branch or a merge request ref
+//     for merge request pipelines, the tag name for tag pipelines)
+//   - Deployment Hook: the deployed ref
+//   - Note Hook, Emoji Hook: the target branch, for merge request comments and reactions
+//
+// Events of other types have no branch and are not filtered. Handlers of new event types
+// with a branch must apply the filter, and be listed here and in the README.
+func (s *GitLabService) HandleEvent(chatID int64, eventType string, payload []byte, opts EventOptions) error {
+	// System hooks deliver events for all projects of the instance, so they are routed
+	// by their payload, and messages are always prefixed with the full project path
+	if eventType == "System Hook" {
+		var event struct {
+			ObjectKind string `json:"object_kind"`
+			EventName  string `json:"event_name"`
+		}
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return err
+		}
+		kind := event.ObjectKind
+		if kind == "" {
+			kind = event.EventName
+		}
+		if projectEventType, ok := systemHookEvents[kind]; ok {
+			eventType = projectEventType
+		}
+		opts.IncludeProject = true
+		opts.IncludeProjectPath = true
+	}
+
 	switch eventType {
 	case "Push Hook":
-		return s.handlePushEvent(chatID, payload, includeProject)
+		return s.handlePushEvent(chatID, payload, opts)
+	case "Tag Push Hook":
+		return s.handleTagPushEvent(chatID, payload, opts)
+	case "Release Hook":
+		return s.handleReleaseEvent(chatID, payload, opts)
 	case "Pipeline Hook":
-		return s.handlePipelineEvent(chatID, payload, includeProject)
+		return s.handlePipelineEvent(chatID, payload, opts)
+	case "Job Hook":
+		return s.handleJobEvent(chatID, payload, opts)
 	case "Merge Request Hook":
-		return s.handleMergeRequestEvent(chatID, payload, includeProject)
-	case "Issue Hook":
-		return s.handleIssueEvent(chatID, payload, includeProject)
+		return s.handleMergeRequestEvent(chatID, payload, opts)
+	// Confidential issues and notes are sent with separate event types, if enabled for the webhook
+	case "Issue Hook", "Confidential Issue Hook":
+		return s.handleIssueEvent(chatID, payload, opts)
+	case "Note Hook", "Confidential Note Hook":
+		return s.handleNoteEvent(chatID, payload, opts)
+	case "Deployment Hook":
+		return s.handleDeploymentEvent(chatID, payload, opts)
+	case "Feature Flag Hook":
+		return s.handleFeatureFlagEvent(chatID, payload, opts)
+	case "Wiki Page Hook":
+		return s.handleWikiPageEvent(chatID, payload, opts)
+	case "Emoji Hook":
+		// Reactions are opt-in, as they would flood the chat
+		if !opts.IncludeEmoji {
+			return nil
+		}
+		return s.handleEmojiEvent(chatID, payload, opts)
+	case "Member Hook":
+		return s.handleMemberEvent(chatID, payload)
+	case "Subgroup Hook":
+		return s.handleSubgroupEvent(chatID, payload)
 	default:
-		return fmt.Errorf("unsupported event type: %s", eventType)
+		// Acknowledge unsupported events, so GitLab doesn't disable the webhook after failed deliveries
+		log.Printf("Unsupported GitLab event type: %s", eventType)
+		unhandledEvents.Add(eventType, 1)
+		if opts.IncludeUnknown {
+			return s.handleUnknownEvent(chatID, eventType, payload, opts)
+		}
+		return nil
+	}
+}
+
+// handleUnknownEvent reports an unsupported event with a generic message
+func (s *GitLabService) handleUnknownEvent(chatID int64, eventType string, payload []byte, opts EventOptions) error {
+	var event struct {
+		ObjectKind       string `json:"object_kind"`
+		EventName        string `json:"event_name"`
+		UserName         string `json:"user_name"`
+		ObjectAttributes struct {
+			Action string `json:"action"`
+		} `json:"object_attributes"`
+		Project struct {
+			Name              string `json:"name"`
+			PathWithNamespace string `json:"path_with_namespace"`
+			WebURL            string `json:"web_url"`
+		} `json:"project"`
+		User struct {
+			Name string `json:"name"`
+		} `json:"user"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Build message
+	var message strings.Builder
+
+	message.WriteString("ℹ️ ")
+	if opts.IncludeProject && event.Project.Name != "" {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(opts.projectName(event.Project.Name, event.Project.PathWithNamespace))))
+	}
+
+	// Different hooks put the user and the event name in different places
+	userName := event.User.Name
+	if userName == "" {
+		userName = event.UserName
+	}
+	eventName := event.ObjectKind
+	if eventName == "" {
+		eventName = event.EventName
 	}
+	if eventName == "" {
+		eventName = eventType
+	}
+	if event.ObjectAttributes.Action != "" {
+		eventName += "." + event.ObjectAttributes.Action
+	}
+
+	if userName != "" {
+		message.WriteString(fmt.Sprintf("<b>%s</b> triggered ", html.EscapeString(userName)))
+	} else {
+		message.WriteString("Triggered ")
+	}
+	message.WriteString(fmt.Sprintf("<code>%s</code>", html.EscapeString(eventName)))
+
+	if event.Project.PathWithNamespace != "" {
+		message.WriteString(fmt.Sprintf(
+			" on <a href=\"%s\">%s</a>",
+			event.Project.WebURL,
+			html.EscapeString(event.Project.PathWithNamespace),
+		))
+	}
+	message.WriteString(".")
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
 }
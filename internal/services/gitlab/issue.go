@@ -7,7 +7,7 @@ import (
 	"strings"
 )
 
-func (s *GitLabService) handleIssueEvent(chatID int64, payload []byte, includeProject bool) error {
+func (s *GitLabService) handleIssueEvent(chatID int64, payload []byte, opts EventOptions) error {
 	var event struct {
 		ObjectAttributes struct {
 			ID          int    `json:"id"`
@@ -61,8 +61,8 @@ func (s *GitLabService) handleIssueEvent(chatID int64, payload []byte, includePr
 	}
 
 	message.WriteString(emoji + " ")
-	if includeProject {
-		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(event.Project.Name)))
+	if opts.IncludeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(opts.projectName(event.Project.Name, event.Project.PathWithNamespace))))
 	}
 
 	message.WriteString(fmt.Sprintf(
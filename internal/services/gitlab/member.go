@@ -0,0 +1,89 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+)
+
+func (s *GitLabService) handleMemberEvent(chatID int64, payload []byte) error {
+	// The payload contains the member's email address, which is deliberately not parsed
+	var event struct {
+		EventName    string `json:"event_name"`
+		GroupName    string `json:"group_name"`
+		GroupPath    string `json:"group_path"`
+		GroupAccess  string `json:"group_access"`
+		UserName     string `json:"user_name"`
+		UserUsername string `json:"user_username"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Add emoji and action based on event name
+	var emoji string
+	var action string
+	switch event.EventName {
+	case "user_add_to_group":
+		emoji = "➕"
+		action = fmt.Sprintf("was added to group <b>%s</b> as %s", html.EscapeString(event.GroupName), html.EscapeString(event.GroupAccess))
+	case "user_update_for_group":
+		emoji = "🔑"
+		action = fmt.Sprintf("now has %s access to group <b>%s</b>", html.EscapeString(event.GroupAccess), html.EscapeString(event.GroupName))
+	case "user_remove_from_group":
+		emoji = "➖"
+		action = fmt.Sprintf("was removed from group <b>%s</b>", html.EscapeString(event.GroupName))
+	case "user_access_request_to_group":
+		emoji = "🙋"
+		action = fmt.Sprintf("requested access to group <b>%s</b>", html.EscapeString(event.GroupName))
+	default:
+		return nil
+	}
+
+	message := fmt.Sprintf(
+		"%s <b>%s</b> (@%s) %s.",
+		emoji,
+		html.EscapeString(event.UserName),
+		html.EscapeString(event.UserUsername),
+		action,
+	)
+
+	return s.telegramSvc.SendMessage(chatID, message)
+}
+
+func (s *GitLabService) handleSubgroupEvent(chatID int64, payload []byte) error {
+	var event struct {
+		EventName      string `json:"event_name"`
+		FullPath       string `json:"full_path"`
+		ParentName     string `json:"parent_name"`
+		ParentFullPath string `json:"parent_full_path"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Build message
+	var message strings.Builder
+
+	switch event.EventName {
+	case "subgroup_create":
+		message.WriteString(fmt.Sprintf(
+			"📁 Subgroup <code>%s</code> was created in group <b>%s</b>.",
+			html.EscapeString(event.FullPath),
+			html.EscapeString(event.ParentName),
+		))
+	case "subgroup_destroy":
+		message.WriteString(fmt.Sprintf(
+			"🗑️ Subgroup <code>%s</code> was deleted from group <b>%s</b>.",
+			html.EscapeString(event.FullPath),
+			html.EscapeString(event.ParentName),
+		))
+	default:
+		return nil
+	}
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
@@ -7,7 +7,7 @@ import (
 	"strings"
 )
 
-func (s *GitLabService) handleMergeRequestEvent(chatID int64, payload []byte, includeProject bool) error {
+func (s *GitLabService) handleMergeRequestEvent(chatID int64, payload []byte, opts EventOptions) error {
 	var event struct {
 		ObjectAttributes struct {
 			ID           int    `json:"id"`
@@ -44,6 +44,11 @@ func (s *GitLabService) handleMergeRequestEvent(chatID int64, payload []byte, in
 		return nil
 	}
 
+	// If branch filter is specified and doesn't match the target branch, skip this event
+	if !opts.BranchFilter.Match(event.ObjectAttributes.TargetBranch) {
+		return nil
+	}
+
 	// Build message
 	var message strings.Builder
 
@@ -75,8 +80,8 @@ func (s *GitLabService) handleMergeRequestEvent(chatID int64, payload []byte, in
 	}
 
 	message.WriteString(emoji + " ")
-	if includeProject {
-		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(event.Project.Name)))
+	if opts.IncludeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(opts.projectName(event.Project.Name, event.Project.PathWithNamespace))))
 	}
 
 	message.WriteString(fmt.Sprintf(
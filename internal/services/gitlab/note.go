@@ -0,0 +1,123 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"git-telegram-bot/internal/services/telegram"
+)
+
+func (s *GitLabService) handleNoteEvent(chatID int64, payload []byte, opts EventOptions) error {
+	var event struct {
+		ObjectAttributes struct {
+			Note         string `json:"note"`
+			NoteableType string `json:"noteable_type"`
+			Action       string `json:"action"`
+			URL          string `json:"url"`
+			System       bool   `json:"system"`
+			Resolved     bool   `json:"resolved"`
+			Position     *struct {
+				NewPath string `json:"new_path"`
+			} `json:"position"`
+		} `json:"object_attributes"`
+		Project struct {
+			Name              string `json:"name"`
+			PathWithNamespace string `json:"path_with_namespace"`
+			WebURL            string `json:"web_url"`
+		} `json:"project"`
+		User struct {
+			Name string `json:"name"`
+		} `json:"user"`
+		MergeRequest struct {
+			IID          int    `json:"iid"`
+			Title        string `json:"title"`
+			TargetBranch string `json:"target_branch"`
+		} `json:"merge_request"`
+		Issue struct {
+			IID   int    `json:"iid"`
+			Title string `json:"title"`
+		} `json:"issue"`
+		Commit struct {
+			ID string `json:"id"`
+		} `json:"commit"`
+		Snippet struct {
+			ID    int    `json:"id"`
+			Title string `json:"title"`
+		} `json:"snippet"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on new comments (older GitLab versions don't send the action)
+	if event.ObjectAttributes.Action != "" && event.ObjectAttributes.Action != "create" {
+		return nil
+	}
+
+	// Skip notes generated by GitLab itself (e.g. "changed the description")
+	if event.ObjectAttributes.System {
+		return nil
+	}
+
+	// Describe what was commented on, based on noteable type
+	var noteable string
+	switch event.ObjectAttributes.NoteableType {
+	case "MergeRequest":
+		// If branch filter is specified and doesn't match the target branch, skip this event
+		if !opts.BranchFilter.Match(event.MergeRequest.TargetBranch) {
+			return nil
+		}
+		noteable = fmt.Sprintf("!%d %s", event.MergeRequest.IID, html.EscapeString(event.MergeRequest.Title))
+	case "Issue":
+		noteable = fmt.Sprintf("#%d %s", event.Issue.IID, html.EscapeString(event.Issue.Title))
+	case "Commit":
+		noteable = fmt.Sprintf("commit <code>%s</code>", html.EscapeString(shortSHA(event.Commit.ID)))
+	case "Snippet":
+		noteable = fmt.Sprintf("$%d %s", event.Snippet.ID, html.EscapeString(event.Snippet.Title))
+	default:
+		return nil
+	}
+
+	// Build message
+	var message strings.Builder
+
+	// Notes in resolved threads are marked distinctly
+	if event.ObjectAttributes.Resolved {
+		message.WriteString("☑️ ")
+	} else {
+		message.WriteString("💬 ")
+	}
+	if opts.IncludeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(opts.projectName(event.Project.Name, event.Project.PathWithNamespace))))
+	}
+
+	message.WriteString(fmt.Sprintf("<b>%s</b> commented on ", html.EscapeString(event.User.Name)))
+
+	// Diff notes are attached to a file
+	if position := event.ObjectAttributes.Position; position != nil && position.NewPath != "" {
+		message.WriteString(fmt.Sprintf("<code>%s</code> in ", html.EscapeString(position.NewPath)))
+	}
+
+	message.WriteString(fmt.Sprintf("<a href=\"%s\">%s</a>", event.ObjectAttributes.URL, noteable))
+	if event.ObjectAttributes.Resolved {
+		message.WriteString(" (resolved thread)")
+	}
+	message.WriteString(".")
+
+	if excerpt := telegram.FormatExcerpt(event.ObjectAttributes.Note); excerpt != "" {
+		message.WriteString("\n" + excerpt)
+	}
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
+
+// shortSHA returns the abbreviated commit hash as shown by GitLab
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
@@ -1,12 +1,14 @@
 package gitlab
 
 import (
-	"cmp"
 	"encoding/json"
 	"fmt"
 	"html"
 	"slices"
 	"strings"
+
+	"git-telegram-bot/internal/services/telegram"
+	"git-telegram-bot/internal/storage"
 )
 
 // PipelineEventData represents the parsed pipeline event data
@@ -43,7 +45,34 @@ type Build = struct {
 	Duration float64 `json:"duration"`
 }
 
-func (s *GitLabService) handlePipelineEvent(chatID int64, payload []byte, includeProject bool) error {
+// JobEventData represents the parsed job event data
+type JobEventData struct {
+	Ref                string  `json:"ref"`
+	BuildID            int64   `json:"build_id"`
+	BuildName          string  `json:"build_name"`
+	BuildStage         string  `json:"build_stage"`
+	BuildStatus        string  `json:"build_status"`
+	BuildDuration      float64 `json:"build_duration"`
+	BuildAllowFailure  bool    `json:"build_allow_failure"`
+	BuildFailureReason string  `json:"build_failure_reason"`
+	PipelineID         int     `json:"pipeline_id"`
+	Project            struct {
+		Name              string `json:"name"`
+		PathWithNamespace string `json:"path_with_namespace"`
+		WebURL            string `json:"web_url"`
+	} `json:"project"`
+}
+
+// pipelineInfo identifies a pipeline message, and can be built from both pipeline and job events
+type pipelineInfo struct {
+	ID          int
+	URL         string
+	ProjectName string
+	ProjectPath string
+	ProjectURL  string
+}
+
+func (s *GitLabService) handlePipelineEvent(chatID int64, payload []byte, opts EventOptions) error {
 	var event PipelineEventData
 
 	if err := json.Unmarshal(payload, &event); err != nil {
@@ -55,134 +84,247 @@ func (s *GitLabService) handlePipelineEvent(chatID int64, payload []byte, includ
 		return nil
 	}
 
+	// Pipelines are filtered by their ref like their jobs, which is the tag name for tag pipelines,
+	// and the source branch (or a merge request ref) for merge request pipelines
+	if !opts.BranchFilter.Match(event.ObjectAttributes.Ref) {
+		return nil
+	}
+
+	info := pipelineInfo{
+		ID:          event.ObjectAttributes.ID,
+		URL:         event.ObjectAttributes.URL,
+		ProjectName: event.Project.Name,
+		ProjectPath: event.Project.PathWithNamespace,
+		ProjectURL:  event.Project.WebURL,
+	}
+
+	// Only the merge request's number is stored, its title is shown from the event at hand
+	var mergeRequestIID int
+	var mergeRequestTitle string
+	if event.MergeRequest != nil {
+		mergeRequestIID = event.MergeRequest.IID
+		mergeRequestTitle = event.MergeRequest.Title
+	}
+
+	// Try to update existing message or create new one
+	return s.telegramSvc.UpdatePipelineMessage(chatID, info.URL, func(pipeline *storage.Pipeline) string {
+		pipeline.Status = event.ObjectAttributes.Status
+		pipeline.Ref = event.ObjectAttributes.Ref
+		pipeline.MergeRequestIID = mergeRequestIID
+
+		// Merge the builds with what job events have reported, as events are not delivered in order
+		for _, build := range event.Builds {
+			setPipelineJob(pipeline, storage.PipelineJob{
+				ID:       int64(build.ID),
+				Name:     build.Name,
+				Status:   build.Status,
+				Duration: build.Duration,
+			})
+		}
+
+		return formatPipelineMessage(info, pipeline, mergeRequestTitle, opts)
+	})
+}
+
+func (s *GitLabService) handleJobEvent(chatID int64, payload []byte, opts EventOptions) error {
+	var event JobEventData
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// If branch filter is specified and doesn't match the job's ref, skip this event
+	if !opts.BranchFilter.Match(event.Ref) {
+		return nil
+	}
+
+	// Jobs are merged into the message of their pipeline, which is keyed by the pipeline URL
+	info := pipelineInfo{
+		ID:          event.PipelineID,
+		URL:         fmt.Sprintf("%s/-/pipelines/%d", event.Project.WebURL, event.PipelineID),
+		ProjectName: event.Project.Name,
+		ProjectPath: event.Project.PathWithNamespace,
+		ProjectURL:  event.Project.WebURL,
+	}
+
+	job := storage.PipelineJob{
+		ID:       event.BuildID,
+		Name:     event.BuildName,
+		Status:   event.BuildStatus,
+		Duration: event.BuildDuration,
+	}
+
+	err := s.telegramSvc.UpdatePipelineMessage(chatID, info.URL, func(pipeline *storage.Pipeline) string {
+		// The job may be reported before its pipeline
+		if pipeline.Status == "" {
+			pipeline.Status = "running"
+		}
+		if pipeline.Ref == "" {
+			pipeline.Ref = event.Ref
+		}
+		setPipelineJob(pipeline, job)
+		// Job events don't include the merge request, so its title is only shown by pipeline events
+		return formatPipelineMessage(info, pipeline, "", opts)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Optionally alert on failed jobs with a separate message, as pipeline messages are edited silently
+	if opts.IncludeFailedJobs && event.BuildStatus == "failed" && !event.BuildAllowFailure {
+		return s.sendFailedJobMessage(chatID, event, info, opts)
+	}
+
+	return nil
+}
+
+// sendFailedJobMessage sends an alert about a failed job with its failure reason
+func (s *GitLabService) sendFailedJobMessage(chatID int64, event JobEventData, info pipelineInfo, opts EventOptions) error {
 	var message strings.Builder
 
-	// Add emoji based on status
-	var emoji string
-	switch event.ObjectAttributes.Status {
-	case "success":
-		emoji = "✅"
-	case "failed":
-		emoji = "❌"
-	case "running":
-		emoji = "🔄"
-	case "pending":
-		emoji = "⏳"
-	case "canceled":
-		emoji = "⚠️"
-	case "skipped":
-		emoji = "⏭️"
-	case "created":
-		emoji = "🛠️"
-	case "waiting_for_resource":
-		emoji = "🚦"
-	case "preparing":
-		emoji = "⚙️"
-	case "manual":
-		emoji = "✋"
-	case "scheduled":
-		emoji = "📅"
-	default:
-		emoji = "ℹ️"
+	message.WriteString("❌ ")
+	if opts.IncludeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(opts.projectName(info.ProjectName, info.ProjectPath))))
 	}
 
-	message.WriteString(emoji + " ")
-	if includeProject {
-		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(event.Project.Name)))
+	message.WriteString(fmt.Sprintf(
+		"Job <a href=\"%s/-/jobs/%d\"><b>%s</b></a> failed in <a href=\"%s\">Pipeline #%d</a> for <code>%s</code>",
+		event.Project.WebURL,
+		event.BuildID,
+		html.EscapeString(event.BuildName),
+		info.URL,
+		info.ID,
+		html.EscapeString(event.Ref),
+	))
+
+	// Replace underscores with spaces in the failure reason
+	if event.BuildFailureReason != "" {
+		message.WriteString(": " + html.EscapeString(strings.ReplaceAll(event.BuildFailureReason, "_", " ")))
 	}
+	message.WriteString(".")
 
-	// Replace underscores with spaces in the status
-	statusDisplay := strings.ReplaceAll(event.ObjectAttributes.Status, "_", " ")
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
 
-	// Build the "for" part based on whether it's for a branch or MR
-	var forPart string
-	if event.MergeRequest != nil {
-		forPart = fmt.Sprintf(
-			"<a href=\"%s\">!%d %s</a>",
-			event.MergeRequest.URL,
-			event.MergeRequest.IID,
-			html.EscapeString(event.MergeRequest.Title),
-		)
-	} else {
-		forPart = fmt.Sprintf("<code>%s</code>", html.EscapeString(event.ObjectAttributes.Ref))
+// setPipelineJob adds or replaces a job of the pipeline, unless the job has already finished
+// and the new state is a stale one from an event delivered late.
+// Job names are unique in a pipeline, and a retried job gets a new, higher ID,
+// so only the job with the highest ID is kept for each name.
+func setPipelineJob(pipeline *storage.Pipeline, job storage.PipelineJob) {
+	for _, stored := range pipeline.Jobs {
+		if stored.Name == job.Name && stored.ID > job.ID {
+			return
+		}
+	}
+	pipeline.Jobs = slices.DeleteFunc(pipeline.Jobs, func(stored storage.PipelineJob) bool {
+		return stored.Name == job.Name && stored.ID < job.ID
+	})
+
+	if stored, ok := pipeline.Job(job.ID); ok && isJobFinished(stored.Status) && !isJobFinished(job.Status) {
+		return
+	}
+	pipeline.SetJob(job)
+}
+
+// isJobFinished reports whether a job (build) status is final. Retried jobs get a new ID,
+// but skipped jobs are processed again with the same ID when the pipeline is retried.
+func isJobFinished(status string) bool {
+	return status == "success" || status == "failed" || status == "canceled"
+}
+
+// formatPipelineMessage renders a pipeline message with the pipeline's jobs known so far
+func formatPipelineMessage(info pipelineInfo, pipeline *storage.Pipeline, mergeRequestTitle string, opts EventOptions) string {
+	var message strings.Builder
+
+	message.WriteString(pipelineStatusEmoji(pipeline.Status) + " ")
+	if opts.IncludeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(opts.projectName(info.ProjectName, info.ProjectPath))))
 	}
 
+	// Replace underscores with spaces in the status
+	statusDisplay := strings.ReplaceAll(pipeline.Status, "_", " ")
+
 	message.WriteString(fmt.Sprintf(
 		"<a href=\"%s\">Pipeline #%d</a> %s for %s",
-		event.ObjectAttributes.URL,
-		event.ObjectAttributes.ID,
+		info.URL,
+		info.ID,
 		html.EscapeString(statusDisplay),
-		forPart,
+		formatPipelineSubject(info, pipeline, mergeRequestTitle),
 	))
 
 	// Add build information
-	if len(event.Builds) > 0 {
-		slices.SortFunc(event.Builds, func(a, b Build) int {
-			return cmp.Compare(a.ID, b.ID)
-		})
+	if len(pipeline.Jobs) > 0 {
 		message.WriteString(":\n")
-		for _, build := range event.Builds {
-			// Add emoji based on build status
-			var buildEmoji string
-			switch build.Status {
-			case "success":
-				buildEmoji = "✅"
-			case "failed":
-				buildEmoji = "❌"
-			case "running":
-				buildEmoji = "🔄"
-			case "pending":
-				buildEmoji = "⏳"
-			case "canceled":
-				buildEmoji = "⚠️"
-			case "canceling":
-				buildEmoji = "🛑"
-			case "skipped":
-				buildEmoji = "⏭️"
-			case "created":
-				buildEmoji = "🛠️"
-			case "manual":
-				buildEmoji = "✋"
-			case "preparing":
-				buildEmoji = "⚙️"
-			case "scheduled":
-				buildEmoji = "📅"
-			case "waiting_for_resource":
-				buildEmoji = "🚦"
-			default:
-				buildEmoji = "ℹ️"
-			}
-
-			// Format duration as string
-			var durationStr string
-			if build.Duration >= 1.0 {
-				durationStr = fmt.Sprintf("%.0f seconds", build.Duration)
-			} else if build.Duration > 0 {
-				durationStr = fmt.Sprintf("%.1f seconds", build.Duration)
-			} else {
-				durationStr = ""
-			}
-
-			if durationStr != "" {
+		for _, job := range pipeline.Jobs {
+			if duration := telegram.FormatDuration(job.Duration); duration != "" {
 				message.WriteString(fmt.Sprintf(
 					"%s <b>%s</b> (%s)\n",
-					buildEmoji,
-					html.EscapeString(build.Name),
-					durationStr,
+					jobStatusEmoji(job.Status),
+					html.EscapeString(job.Name),
+					duration,
 				))
 			} else {
 				message.WriteString(fmt.Sprintf(
 					"%s <b>%s</b>\n",
-					buildEmoji,
-					html.EscapeString(build.Name),
+					jobStatusEmoji(job.Status),
+					html.EscapeString(job.Name),
 				))
 			}
 		}
 	}
 
-	// Get pipeline URL for updating existing messages
-	pipelineURL := event.ObjectAttributes.URL
+	return message.String()
+}
 
-	// Try to update existing message or create new one
-	return s.telegramSvc.SendOrUpdatePipelineMessage(chatID, pipelineURL, message.String())
+// formatPipelineSubject renders what the pipeline runs for: a link to its merge request, or its branch or tag
+func formatPipelineSubject(info pipelineInfo, pipeline *storage.Pipeline, mergeRequestTitle string) string {
+	if pipeline.MergeRequestIID == 0 {
+		return fmt.Sprintf("<code>%s</code>", html.EscapeString(pipeline.Ref))
+	}
+
+	text := fmt.Sprintf("!%d", pipeline.MergeRequestIID)
+	if mergeRequestTitle != "" {
+		text += " " + html.EscapeString(mergeRequestTitle)
+	}
+	return fmt.Sprintf("<a href=\"%s/-/merge_requests/%d\">%s</a>", info.ProjectURL, pipeline.MergeRequestIID, text)
+}
+
+// pipelineStatusEmoji returns the emoji for a pipeline status
+func pipelineStatusEmoji(status string) string {
+	switch status {
+	case "success":
+		return "✅"
+	case "failed":
+		return "❌"
+	case "running":
+		return "🔄"
+	case "pending":
+		return "⏳"
+	case "canceled":
+		return "⚠️"
+	case "skipped":
+		return "⏭️"
+	case "created":
+		return "🛠️"
+	case "waiting_for_resource":
+		return "🚦"
+	case "preparing":
+		return "⚙️"
+	case "manual":
+		return "✋"
+	case "scheduled":
+		return "📅"
+	default:
+		return "ℹ️"
+	}
+}
+
+// jobStatusEmoji returns the emoji for a job (build) status
+func jobStatusEmoji(status string) string {
+	switch status {
+	case "canceling":
+		return "🛑"
+	default:
+		return pipelineStatusEmoji(status)
+	}
 }
@@ -9,7 +9,7 @@ import (
 	"git-telegram-bot/internal/services/telegram"
 )
 
-func (s *GitLabService) handlePushEvent(chatID int64, payload []byte, includeProject bool) error {
+func (s *GitLabService) handlePushEvent(chatID int64, payload []byte, opts EventOptions) error {
 	var event struct {
 		Ref      string `json:"ref"`
 		Before   string `json:"before"`
@@ -39,6 +39,11 @@ func (s *GitLabService) handlePushEvent(chatID int64, payload []byte, includePro
 	// Extract branch name from ref
 	branch := strings.TrimPrefix(event.Ref, "refs/heads/")
 
+	// If branch filter is specified and doesn't match the current branch, skip this event
+	if !opts.BranchFilter.Match(branch) {
+		return nil
+	}
+
 	// Build message
 	var message strings.Builder
 
@@ -53,8 +58,8 @@ func (s *GitLabService) handlePushEvent(chatID int64, payload []byte, includePro
 	}
 
 	// Add project name if requested
-	if includeProject {
-		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(event.Project.Name)))
+	if opts.IncludeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(opts.projectName(event.Project.Name, event.Project.PathWithNamespace))))
 	}
 
 	// Write event-specific message
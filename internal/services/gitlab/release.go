@@ -0,0 +1,96 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"git-telegram-bot/internal/services/telegram"
+)
+
+func (s *GitLabService) handleReleaseEvent(chatID int64, payload []byte, opts EventOptions) error {
+	var event struct {
+		Action      string `json:"action"`
+		Name        string `json:"name"`
+		Tag         string `json:"tag"`
+		Description string `json:"description"`
+		URL         string `json:"url"`
+		Project     struct {
+			Name              string `json:"name"`
+			PathWithNamespace string `json:"path_with_namespace"`
+			WebURL            string `json:"web_url"`
+		} `json:"project"`
+		Assets struct {
+			Links []struct {
+				Name string `json:"name"`
+				URL  string `json:"url"`
+			} `json:"links"`
+		} `json:"assets"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on known release actions
+	if event.Action != "create" && event.Action != "update" {
+		return nil
+	}
+
+	// Build message
+	var message strings.Builder
+
+	// Add emoji based on action
+	var emoji string
+	var action string
+	switch event.Action {
+	case "create":
+		emoji = "🎉"
+		action = "published"
+	case "update":
+		emoji = "✏️"
+		action = "updated"
+	default:
+		emoji = "ℹ️"
+		action = event.Action
+	}
+
+	// Releases don't have to be named, fall back to the tag
+	name := event.Name
+	if name == "" {
+		name = event.Tag
+	}
+
+	message.WriteString(emoji + " ")
+	if opts.IncludeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(opts.projectName(event.Project.Name, event.Project.PathWithNamespace))))
+	}
+
+	// Release hooks don't tell who made the change
+	message.WriteString(fmt.Sprintf(
+		"Release <a href=\"%s\">%s</a> (<code>%s</code>) %s.",
+		event.URL,
+		html.EscapeString(name),
+		html.EscapeString(event.Tag),
+		action,
+	))
+
+	// Only include release notes when the release is announced
+	if event.Action == "create" {
+		if excerpt := telegram.FormatExcerpt(event.Description); excerpt != "" {
+			message.WriteString("\n" + excerpt)
+		}
+	}
+
+	// Add asset links (source archives are generated for every release and are skipped)
+	for _, link := range event.Assets.Links {
+		message.WriteString(fmt.Sprintf(
+			"\n📦 <a href=\"%s\">%s</a>",
+			link.URL,
+			html.EscapeString(link.Name),
+		))
+	}
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
@@ -0,0 +1,79 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"git-telegram-bot/internal/services/telegram"
+)
+
+func (s *GitLabService) handleTagPushEvent(chatID int64, payload []byte, opts EventOptions) error {
+	var event struct {
+		Ref      string `json:"ref"`
+		Before   string `json:"before"`
+		After    string `json:"after"`
+		UserName string `json:"user_name"`
+		Message  string `json:"message"`
+		Project  struct {
+			Name              string `json:"name"`
+			PathWithNamespace string `json:"path_with_namespace"`
+			WebURL            string `json:"web_url"`
+		} `json:"project"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Extract tag name from ref
+	tag := strings.TrimPrefix(event.Ref, "refs/tags/")
+
+	// Tags have no branch, so the branch filter is matched against the tag name
+	if !opts.BranchFilter.Match(tag) {
+		return nil
+	}
+
+	// Build message
+	var message strings.Builder
+
+	// Check if this is a tag deletion event (after hash is all zeros)
+	isTagDeletion := event.After == "0000000000000000000000000000000000000000"
+
+	// Write emoji based on event type
+	if isTagDeletion {
+		message.WriteString("🗑️ ")
+	} else {
+		message.WriteString("🏷️ ")
+	}
+
+	// Add project name if requested
+	if opts.IncludeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(opts.projectName(event.Project.Name, event.Project.PathWithNamespace))))
+	}
+
+	// Write event-specific message
+	if isTagDeletion {
+		message.WriteString(fmt.Sprintf(
+			"<b>%s</b> deleted tag <code>%s</code>.",
+			html.EscapeString(event.UserName),
+			html.EscapeString(tag),
+		))
+	} else {
+		message.WriteString(fmt.Sprintf(
+			"<b>%s</b> created tag <a href=\"%s/-/tags/%s\"><code>%s</code></a>.",
+			html.EscapeString(event.UserName),
+			event.Project.WebURL,
+			html.EscapeString(tag),
+			html.EscapeString(tag),
+		))
+
+		// Annotated tags have a message
+		if excerpt := telegram.FormatExcerpt(event.Message); excerpt != "" {
+			message.WriteString("\n" + excerpt)
+		}
+	}
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
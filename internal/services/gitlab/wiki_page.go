@@ -0,0 +1,89 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+)
+
+func (s *GitLabService) handleWikiPageEvent(chatID int64, payload []byte, opts EventOptions) error {
+	var event struct {
+		ObjectAttributes struct {
+			Title   string `json:"title"`
+			Action  string `json:"action"`
+			URL     string `json:"url"`
+			DiffURL string `json:"diff_url"`
+		} `json:"object_attributes"`
+		Project struct {
+			Name              string `json:"name"`
+			PathWithNamespace string `json:"path_with_namespace"`
+			WebURL            string `json:"web_url"`
+		} `json:"project"`
+		User struct {
+			Name string `json:"name"`
+		} `json:"user"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	// Only notify on known wiki page actions
+	if event.ObjectAttributes.Action != "create" &&
+		event.ObjectAttributes.Action != "update" &&
+		event.ObjectAttributes.Action != "delete" {
+		return nil
+	}
+
+	// Build message
+	var message strings.Builder
+
+	// Add emoji based on action
+	var emoji string
+	var action string
+	switch event.ObjectAttributes.Action {
+	case "create":
+		emoji = "📄"
+		action = "created"
+	case "update":
+		emoji = "📝"
+		action = "updated"
+	case "delete":
+		emoji = "🗑️"
+		action = "deleted"
+	default:
+		emoji = "ℹ️"
+		action = event.ObjectAttributes.Action
+	}
+
+	message.WriteString(emoji + " ")
+	if opts.IncludeProject {
+		message.WriteString(fmt.Sprintf("<b>%s</b>: ", html.EscapeString(opts.projectName(event.Project.Name, event.Project.PathWithNamespace))))
+	}
+
+	// Deleted pages can't be linked
+	if event.ObjectAttributes.Action == "delete" {
+		message.WriteString(fmt.Sprintf(
+			"<b>%s</b> %s wiki page <b>%s</b>.",
+			html.EscapeString(event.User.Name),
+			action,
+			html.EscapeString(event.ObjectAttributes.Title),
+		))
+	} else {
+		message.WriteString(fmt.Sprintf(
+			"<b>%s</b> %s wiki page <a href=\"%s\">%s</a>",
+			html.EscapeString(event.User.Name),
+			action,
+			event.ObjectAttributes.URL,
+			html.EscapeString(event.ObjectAttributes.Title),
+		))
+		// Older GitLab versions don't send the diff URL
+		if event.ObjectAttributes.Action == "update" && event.ObjectAttributes.DiffURL != "" {
+			message.WriteString(fmt.Sprintf(" (<a href=\"%s\">diff</a>)", event.ObjectAttributes.DiffURL))
+		}
+		message.WriteString(".")
+	}
+
+	return s.telegramSvc.SendMessage(chatID, message.String())
+}
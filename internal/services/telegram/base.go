@@ -2,8 +2,12 @@ package telegram
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -14,19 +18,25 @@ import (
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
+	"gocloud.dev/gcerrors"
 )
 
 // TelegramService provides common functionality for Telegram bots
 type TelegramService struct {
-	botId       string // Internal bot ID (github or gitlab)
-	bot         *bot.Bot
-	chatStorage *storage.ChatStorage
+	botId           string // Internal bot ID (github or gitlab)
+	bot             *bot.Bot
+	chatStorage     *storage.ChatStorage
+	webhookStorage  *storage.WebhookStorage
+	pipelineStorage *storage.PipelineStorage
 }
 
 var (
 	webhookSecretToken string
 )
 
+// ErrUnknownWebhook is returned when a webhook URL doesn't resolve to a chat
+var ErrUnknownWebhook = errors.New("unknown webhook")
+
 func getWebhookSecretToken() string {
 	if webhookSecretToken == "" {
 		webhookSecretToken = fmt.Sprintf("%x", sha256.Sum256(fmt.Appendf(nil, "%s:%s", config.Global.SecretKey, "telegram")))
@@ -55,9 +65,11 @@ func NewTelegramService(botId string, token string, storageInstance *storage.Sto
 	}
 
 	return &TelegramService{
-		bot:         botInstance,
-		botId:       botId,
-		chatStorage: storageInstance.ChatStorage,
+		bot:             botInstance,
+		botId:           botId,
+		chatStorage:     storageInstance.ChatStorage,
+		webhookStorage:  storageInstance.WebhookStorage,
+		pipelineStorage: storageInstance.PipelineStorage,
 	}, nil
 }
 
@@ -80,6 +92,54 @@ func (s *TelegramService) RegisterCommandHandler(command string, handler bot.Han
 	s.bot.RegisterHandlerMatchFunc(matchFunc, handler)
 }
 
+// RegisterAdminCommandHandler registers a privileged command that only administrators can run in group chats
+func (s *TelegramService) RegisterAdminCommandHandler(command string, handler bot.HandlerFunc) {
+	s.RegisterCommandHandler(command, s.requireAdmin(handler))
+}
+
+// requireAdmin wraps a command handler to refuse non-administrators
+func (s *TelegramService) requireAdmin(handler bot.HandlerFunc) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		isAdmin, err := s.isChatAdmin(ctx, update.Message)
+		if err != nil {
+			log.Printf("Failed to check %s bot admin rights in chat %d: %v", s.botId, update.Message.Chat.ID, err)
+			s.SendMessageOrLogError(update.Message.Chat.ID, "⚠️ Failed to check your permissions, please try again later.")
+			return
+		}
+		if !isAdmin {
+			s.SendMessageOrLogError(update.Message.Chat.ID, "⛔ Only chat administrators can use this command.")
+			return
+		}
+		handler(ctx, b, update)
+	}
+}
+
+// isChatAdmin checks whether the message sender may run privileged commands in the chat
+func (s *TelegramService) isChatAdmin(ctx context.Context, message *models.Message) (bool, error) {
+	// Private chats only have one user
+	if message.Chat.Type == models.ChatTypePrivate {
+		return true, nil
+	}
+
+	// Anonymous administrators send messages on behalf of the chat itself
+	if message.SenderChat != nil && message.SenderChat.ID == message.Chat.ID {
+		return true, nil
+	}
+
+	if message.From == nil {
+		return false, nil
+	}
+
+	member, err := s.bot.GetChatMember(ctx, &bot.GetChatMemberParams{
+		ChatID: message.Chat.ID,
+		UserID: message.From.ID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return member.Type == models.ChatMemberTypeOwner || member.Type == models.ChatMemberTypeAdministrator, nil
+}
+
 // StartBot starts a Telegram bot
 func (s *TelegramService) StartBot(ctx context.Context) {
 	s.bot.StartWebhook(ctx)
@@ -131,8 +191,134 @@ func (s *TelegramService) WebhookHandler() http.HandlerFunc {
 	}
 }
 
-func (s *TelegramService) GetChatWebhookURL(chatID int64) string {
-	return fmt.Sprintf("%s/%s/%d", config.Global.BaseURL, s.botId, chatID)
+// GetChatWebhookToken returns the chat's webhook URL token, issuing a new one if the chat has none
+func (s *TelegramService) GetChatWebhookToken(chatID int64) (string, error) {
+	ctx := context.Background()
+	chatWebhook, err := s.webhookStorage.GetChatWebhook(ctx, s.botId, chatID)
+	if err != nil {
+		return "", err
+	}
+	if chatWebhook != nil {
+		return chatWebhook.WebhookToken, nil
+	}
+
+	token, err := s.createWebhookToken(ctx, chatID)
+	if err != nil {
+		return "", err
+	}
+
+	// Record the token only if the chat still has none, so that concurrent calls agree on one token
+	if err := s.webhookStorage.CreateChatWebhook(ctx, s.botId, chatID, token); err != nil {
+		// The chat doesn't point to the new token, so it could never be revoked
+		s.deleteWebhookTokenOrLogError(ctx, token)
+
+		// Return the token recorded by a concurrent call, if any
+		chatWebhook, getErr := s.webhookStorage.GetChatWebhook(ctx, s.botId, chatID)
+		if getErr == nil && chatWebhook != nil {
+			return chatWebhook.WebhookToken, nil
+		}
+		return "", fmt.Errorf("Failed to record webhook token: %w", err)
+	}
+	return token, nil
+}
+
+// RotateChatWebhookToken issues a new webhook URL token for the chat and invalidates the previous one.
+// Only the calling bot's token is affected.
+func (s *TelegramService) RotateChatWebhookToken(chatID int64) (string, error) {
+	ctx := context.Background()
+	chatWebhook, err := s.webhookStorage.GetChatWebhook(ctx, s.botId, chatID)
+	if err != nil {
+		return "", err
+	}
+
+	// Invalidate the previous token first, so that it is revoked even if the rotation fails later on
+	if chatWebhook != nil {
+		if err := s.webhookStorage.DeleteWebhook(ctx, chatWebhook.WebhookToken); err != nil {
+			return "", fmt.Errorf("Failed to delete previous webhook token: %w", err)
+		}
+	}
+
+	token, err := s.createWebhookToken(ctx, chatID)
+	if err != nil {
+		return "", err
+	}
+
+	// Conditional writes make concurrent rotations fail instead of overwriting each other's tokens
+	if chatWebhook == nil {
+		err = s.webhookStorage.CreateChatWebhook(ctx, s.botId, chatID, token)
+	} else {
+		err = s.webhookStorage.ReplaceChatWebhookToken(ctx, chatWebhook, token)
+	}
+	if err != nil {
+		// The chat doesn't point to the new token, so it could never be revoked
+		s.deleteWebhookTokenOrLogError(ctx, token)
+		return "", fmt.Errorf("Failed to record webhook token: %w", err)
+	}
+	return token, nil
+}
+
+// GetWebhookURL returns the webhook URL for a webhook key (token or legacy chat ID)
+func (s *TelegramService) GetWebhookURL(webhookKey string) string {
+	return fmt.Sprintf("%s/%s/%s", config.Global.BaseURL, s.botId, webhookKey)
+}
+
+// createWebhookToken generates and stores a new random webhook token for the chat
+func (s *TelegramService) createWebhookToken(ctx context.Context, chatID int64) (string, error) {
+	buf := make([]byte, 18)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+
+	webhook := &storage.Webhook{
+		Token:   token,
+		ChatID:  chatID,
+		BotType: s.botId,
+	}
+	if err := s.webhookStorage.CreateWebhook(ctx, webhook); err != nil {
+		return "", fmt.Errorf("Failed to store webhook token: %w", err)
+	}
+	return token, nil
+}
+
+// deleteWebhookTokenOrLogError deletes a webhook token that no chat points to
+func (s *TelegramService) deleteWebhookTokenOrLogError(ctx context.Context, token string) {
+	if err := s.webhookStorage.DeleteWebhook(ctx, token); err != nil {
+		log.Printf("Failed to delete unused webhook token: %v", err)
+	}
+}
+
+// IsLegacyWebhookKey reports whether a webhook key is an accepted legacy chat ID.
+// Legacy webhooks were created without a secret, so their deliveries can't be authenticated.
+func (s *TelegramService) IsLegacyWebhookKey(webhookKey string) bool {
+	if !config.Global.LegacyWebhookURLs {
+		return false
+	}
+	_, err := ParseChatID(webhookKey)
+	return err == nil
+}
+
+// ResolveWebhookChatID returns the chat ID that a webhook key (token or legacy chat ID) delivers to
+func (s *TelegramService) ResolveWebhookChatID(webhookKey string) (int64, error) {
+	if s.IsLegacyWebhookKey(webhookKey) {
+		return ParseChatID(webhookKey)
+	}
+
+	webhook, err := s.webhookStorage.GetWebhook(context.Background(), webhookKey)
+	if err != nil {
+		return 0, err
+	}
+	if webhook == nil || webhook.BotType != s.botId {
+		return 0, ErrUnknownWebhook
+	}
+	return webhook.ChatID, nil
+}
+
+// GetWebhookSecret returns the secret that authenticates deliveries to a webhook key
+func (s *TelegramService) GetWebhookSecret(webhookKey string) string {
+	mac := hmac.New(sha256.New, []byte(config.Global.SecretKey))
+	fmt.Fprintf(mac, "%s:%s", s.botId, webhookKey)
+	return fmt.Sprintf("%x", mac.Sum(nil))
 }
 
 // SetCommands sets the list of available commands for the bot
@@ -209,14 +395,30 @@ func (s *TelegramService) SendMessageWithResult(chatID int64, text string) (*mod
 			log.Printf("Failed to save chat info: %v", err)
 		}
 	} else if isBotBlockedError(err) {
-		if err := s.chatStorage.DeleteChat(ctx, chat); err != nil {
-			log.Printf("Failed to delete chat info: %v", err)
-		}
+		s.forgetChat(ctx, chat)
 	}
 
 	return msg, err
 }
 
+// forgetChat deletes all data stored for a chat, including the bot's webhook token
+func (s *TelegramService) forgetChat(ctx context.Context, chat *storage.Chat) {
+	chatWebhook, err := s.webhookStorage.GetChatWebhook(ctx, chat.BotType, chat.ChatID)
+	if err != nil {
+		log.Printf("Failed to get chat webhook: %v", err)
+	} else if chatWebhook != nil {
+		if err := s.webhookStorage.DeleteWebhook(ctx, chatWebhook.WebhookToken); err != nil {
+			log.Printf("Failed to delete webhook token: %v", err)
+		}
+		if err := s.webhookStorage.DeleteChatWebhook(ctx, chat.BotType, chat.ChatID); err != nil {
+			log.Printf("Failed to delete chat webhook: %v", err)
+		}
+	}
+	if err := s.chatStorage.DeleteChat(ctx, chat); err != nil {
+		log.Printf("Failed to delete chat info: %v", err)
+	}
+}
+
 // UpdateMessage updates an existing message in a Telegram chat
 func (s *TelegramService) UpdateMessage(chatID int64, messageID int, text string) error {
 	ctx := context.Background()
@@ -234,6 +436,68 @@ func (s *TelegramService) UpdateMessage(chatID int64, messageID int, text string
 	return err
 }
 
+// maxPipelineUpdateAttempts limits retries when concurrent updates of the same pipeline collide
+const maxPipelineUpdateAttempts = 5
+
+// UpdatePipelineMessage renders a pipeline (or workflow run) message and updates the existing message or creates a new one.
+// The render function may change the pipeline state, which is stored for the next update.
+// If another update changes the state concurrently, before the state is stored or before the message is edited
+// (so that the edits may land out of order), the update is applied again to the fresh state.
+func (s *TelegramService) UpdatePipelineMessage(chatID int64, pipelineURL string, render func(pipeline *storage.Pipeline) string) error {
+	ctx := context.Background()
+	pipelineUpdateKey := storage.CreatePipelineUpdateKey(pipelineURL, chatID)
+
+	for attempt := 1; ; attempt++ {
+		// Get existing pipeline mapping
+		pipeline, err := s.pipelineStorage.GetPipeline(ctx, pipelineUpdateKey)
+		if err != nil {
+			return err
+		}
+
+		if pipeline == nil {
+			// Pipeline not found, send new message
+			pipeline = &storage.Pipeline{
+				PipelineUpdateKey: pipelineUpdateKey,
+			}
+			msg, err := s.SendMessageWithResult(chatID, render(pipeline))
+			if err != nil {
+				return err
+			}
+
+			// Store pipeline mapping
+			pipeline.MessageID = msg.ID
+			return s.pipelineStorage.SavePipeline(ctx, pipeline)
+		}
+
+		// Store the new state (and update the mapping timestamp)
+		text := render(pipeline)
+		err = s.pipelineStorage.SavePipeline(ctx, pipeline)
+		if gcerrors.Code(err) == gcerrors.FailedPrecondition && attempt < maxPipelineUpdateAttempts {
+			// Someone else has updated the pipeline in the meantime
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		// Update the existing message
+		if err := s.UpdateMessage(chatID, pipeline.MessageID, text); err != nil && !isMessageNotModifiedError(err) {
+			return err
+		}
+
+		// A concurrent update may have stored a newer state and edited the message before us,
+		// in which case our edit has overwritten it with an outdated text
+		changed, err := s.pipelineStorage.IsPipelineChanged(ctx, pipeline)
+		if err != nil {
+			return err
+		}
+		if changed && attempt < maxPipelineUpdateAttempts {
+			continue
+		}
+		return nil
+	}
+}
+
 // isBotBlockedError checks if the error indicates the bot was blocked or removed
 func isBotBlockedError(err error) bool {
 	if err == nil {
@@ -246,3 +510,8 @@ func isBotBlockedError(err error) bool {
 		strings.Contains(errStr, "chat not found") ||
 		strings.Contains(errStr, "bot is not a member")
 }
+
+// isMessageNotModifiedError checks if the error indicates an edit that didn't change the message
+func isMessageNotModifiedError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "message is not modified")
+}
@@ -20,3 +20,33 @@ func FormatCommitLink(message, url string) string {
 	}
 	return link
 }
+
+// excerptMaxLength is the maximum number of characters kept by FormatExcerpt
+const excerptMaxLength = 300
+
+// FormatExcerpt returns the beginning of a user-written text (comment, review, release notes)
+// as an HTML blockquote, shortened to excerptMaxLength characters with " …" appended.
+// The text is HTML-escaped for safe display. Returns "" for blank texts.
+func FormatExcerpt(text string) string {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	if text == "" {
+		return ""
+	}
+
+	runes := []rune(text)
+	if len(runes) > excerptMaxLength {
+		text = strings.TrimSpace(string(runes[:excerptMaxLength])) + " …"
+	}
+
+	return "<blockquote>" + html.EscapeString(text) + "</blockquote>"
+}
+
+// FormatDuration returns a human-readable duration, or "" if the duration is unknown.
+func FormatDuration(seconds float64) string {
+	if seconds >= 1.0 {
+		return fmt.Sprintf("%.0f seconds", seconds)
+	} else if seconds > 0 {
+		return fmt.Sprintf("%.1f seconds", seconds)
+	}
+	return ""
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"html"
+	"log"
 
 	"git-telegram-bot/internal/config"
 	"git-telegram-bot/internal/services/telegram"
@@ -29,7 +30,8 @@ func NewGitHubTelegramService(storageInstance *storage.Storage) (*GitHubTelegram
 
 	s.RegisterCommandHandler("start", gs.handleStartCommand)
 	s.RegisterCommandHandler("help", gs.handleHelpCommand)
-	s.RegisterCommandHandler("webhook", gs.handleWebhookCommand)
+	s.RegisterAdminCommandHandler("webhook", gs.handleWebhookCommand)
+	s.RegisterAdminCommandHandler("revoke", gs.handleRevokeCommand)
 
 	return gs, nil
 }
@@ -50,6 +52,10 @@ var (
 			Command:     "webhook",
 			Description: "Get your unique GitHub webhook URL",
 		},
+		{
+			Command:     "revoke",
+			Description: "Revoke your webhook URL and get a new one",
+		},
 	}
 )
 
@@ -78,7 +84,8 @@ func (s *GitHubTelegramService) handleHelpCommand(ctx context.Context, b *bot.Bo
 	text := "📚 <b>Available Commands</b>\n\n" +
 		"• /start - Start the bot\n" +
 		"• /help - Show this help message\n" +
-		"• /webhook - Get your unique GitHub webhook URL\n\n" +
+		"• /webhook - Get your unique GitHub webhook URL\n" +
+		"• /revoke - Revoke your webhook URL and get a new one\n\n" +
 		"To set up webhooks, use the appropriate command and add the URL to your repository's webhook settings."
 
 	s.SendMessageOrLogError(update.Message.Chat.ID, text)
@@ -86,21 +93,49 @@ func (s *GitHubTelegramService) handleHelpCommand(ctx context.Context, b *bot.Bo
 
 // handleGitHubCommand handles the /github command
 func (s *GitHubTelegramService) handleWebhookCommand(ctx context.Context, b *bot.Bot, update *models.Update) {
-	webhookURL := s.GetChatWebhookURL(update.Message.Chat.ID)
+	webhookToken, err := s.GetChatWebhookToken(update.Message.Chat.ID)
+	if err != nil {
+		log.Printf("Failed to get webhook token for chat %d: %v", update.Message.Chat.ID, err)
+		s.SendMessageOrLogError(update.Message.Chat.ID, "⚠️ Failed to create a webhook URL, please try again later.")
+		return
+	}
+	webhookURL := s.GetWebhookURL(webhookToken)
+	webhookSecret := s.GetWebhookSecret(webhookToken)
 
 	// Create response message
 	text := fmt.Sprintf("🔗 <b>Your GitHub Webhook URL</b>\n\n<code>%s</code>\n\n", webhookURL) +
+		fmt.Sprintf("🔑 <b>Secret</b>\n\n<code>%s</code>\n\n", webhookSecret) +
 		"<b>How to set up:</b>\n\n" +
 		"1. Go to your GitHub repository\n" +
 		"2. Click on Settings → Webhooks → Add webhook\n" +
 		"3. Paste the URL above in the 'Payload URL' field\n" +
 		"4. Set Content type to 'application/json'\n" +
-		"5. Select the events you want to receive\n" +
-		"6. Click 'Add webhook'\n\n" +
+		"5. Paste the secret above in the 'Secret' field\n" +
+		"6. Select the events you want to receive\n" +
+		"7. Click 'Add webhook'\n\n" +
 		"You'll receive a confirmation message when the webhook is set up correctly.\n\n" +
 		"<b>Optional parameters:</b>\n\n" +
 		"• <code>" + html.EscapeString("?project=1") + "</code> — include project name in messages\n" +
-		"• <code>" + html.EscapeString("?branch=main") + "</code> — filter events by branch"
+		"• <code>" + html.EscapeString("?branch=main&branch=release/*") + "</code> — filter events by branch (globs, <code>!</code> to exclude)\n" +
+		"• <code>" + html.EscapeString("?community=1") + "</code> — report discussions, stars and forks\n" +
+		"• <code>" + html.EscapeString("?unknown=1") + "</code> — report unsupported events with a generic message"
+
+	s.SendMessageOrLogError(update.Message.Chat.ID, text)
+}
+
+// handleRevokeCommand handles the /revoke command
+func (s *GitHubTelegramService) handleRevokeCommand(ctx context.Context, b *bot.Bot, update *models.Update) {
+	webhookToken, err := s.RotateChatWebhookToken(update.Message.Chat.ID)
+	if err != nil {
+		log.Printf("Failed to rotate webhook token for chat %d: %v", update.Message.Chat.ID, err)
+		s.SendMessageOrLogError(update.Message.Chat.ID, "⚠️ Failed to revoke the webhook URL, please try again later.")
+		return
+	}
+
+	text := "♻️ <b>Webhook URL revoked</b>\n\n" +
+		"The previous URL and secret no longer work. Update your GitHub webhook settings with the new ones.\n\n" +
+		fmt.Sprintf("🔗 <b>Your GitHub Webhook URL</b>\n\n<code>%s</code>\n\n", s.GetWebhookURL(webhookToken)) +
+		fmt.Sprintf("🔑 <b>Secret</b>\n\n<code>%s</code>", s.GetWebhookSecret(webhookToken))
 
 	s.SendMessageOrLogError(update.Message.Chat.ID, text)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"html"
+	"log"
 
 	"git-telegram-bot/internal/config"
 	"git-telegram-bot/internal/services/telegram"
@@ -16,7 +17,6 @@ import (
 // GitLabTelegramService is a Telegram service for GitLab notifications
 type GitLabTelegramService struct {
 	*telegram.TelegramService
-	pipelineStorage *storage.PipelineStorage
 }
 
 // NewGitLabTelegramService creates a new GitLab Telegram service
@@ -28,12 +28,12 @@ func NewGitLabTelegramService(storageInstance *storage.Storage) (*GitLabTelegram
 
 	gs := &GitLabTelegramService{
 		TelegramService: s,
-		pipelineStorage: storageInstance.PipelineStorage,
 	}
 
 	s.RegisterCommandHandler("start", gs.handleStartCommand)
 	s.RegisterCommandHandler("help", gs.handleHelpCommand)
-	s.RegisterCommandHandler("webhook", gs.handleWebhookCommand)
+	s.RegisterAdminCommandHandler("webhook", gs.handleWebhookCommand)
+	s.RegisterAdminCommandHandler("revoke", gs.handleRevokeCommand)
 
 	return gs, nil
 }
@@ -54,6 +54,10 @@ var (
 			Command:     "webhook",
 			Description: "Get your unique GitLab webhook URL",
 		},
+		{
+			Command:     "revoke",
+			Description: "Revoke your webhook URL and get a new one",
+		},
 	}
 )
 
@@ -82,7 +86,8 @@ func (s *GitLabTelegramService) handleHelpCommand(ctx context.Context, b *bot.Bo
 	text := "📚 <b>Available Commands</b>\n\n" +
 		"• /start - Start the bot\n" +
 		"• /help - Show this help message\n" +
-		"• /webhook - Get your unique GitLab webhook URL\n\n" +
+		"• /webhook - Get your unique GitLab webhook URL\n" +
+		"• /revoke - Revoke your webhook URL and get a new one\n\n" +
 		"To set up webhooks, use the appropriate command and add the URL to your repository's webhook settings."
 
 	s.SendMessageOrLogError(update.Message.Chat.ID, text)
@@ -90,58 +95,65 @@ func (s *GitLabTelegramService) handleHelpCommand(ctx context.Context, b *bot.Bo
 
 // handleWebhookCommand handles the /webhook command
 func (s *GitLabTelegramService) handleWebhookCommand(ctx context.Context, b *bot.Bot, update *models.Update) {
-	webhookURL := s.GetChatWebhookURL(update.Message.Chat.ID)
+	webhookToken, err := s.GetChatWebhookToken(update.Message.Chat.ID)
+	if err != nil {
+		log.Printf("Failed to get webhook token for chat %d: %v", update.Message.Chat.ID, err)
+		s.SendMessageOrLogError(update.Message.Chat.ID, "⚠️ Failed to create a webhook URL, please try again later.")
+		return
+	}
+	webhookURL := s.GetWebhookURL(webhookToken)
+	webhookSecret := s.GetWebhookSecret(webhookToken)
 
 	// Create response message
 	text := fmt.Sprintf("🔗 <b>Your GitLab Webhook URL</b>\n\n<code>%s</code>\n\n", webhookURL) +
+		fmt.Sprintf("🔑 <b>Secret Token</b>\n\n<code>%s</code>\n\n", webhookSecret) +
 		"<b>How to set up:</b>\n\n" +
-		"1. Go to your GitLab project\n" +
+		"1. Go to your GitLab project or group\n" +
 		"2. Click on Settings → Webhooks\n" +
 		"3. Click 'Add new webhook'\n" +
 		"4. Paste the URL above in the 'URL' field\n" +
-		"5. Select the events you want to receive:\n" +
+		"5. Paste the token above in the 'Secret token' field\n" +
+		"6. Select the events you want to receive:\n" +
 		"   • Push events\n" +
+		"   • Tag push events\n" +
 		"   • Merge request events\n" +
 		"   • Pipeline events\n" +
+		"   • Job events\n" +
 		"   • Issues events\n" +
-		"6. Click 'Add webhook'\n\n" +
+		"   • Comments\n" +
+		"   • Releases events\n" +
+		"   • Deployment events\n" +
+		"   • Feature flag events\n" +
+		"   • Wiki page events\n" +
+		"   • Emoji events\n" +
+		"   • Member and subgroup events (group webhooks)\n" +
+		"7. Click 'Add webhook'\n\n" +
 		"Use the 'Test' button to test the webhook.\n\n" +
+		"On self-managed GitLab, the URL can also be added as a system hook in the Admin area.\n\n" +
 		"<b>Optional parameters:</b>\n\n" +
-		"• <code>" + html.EscapeString("?project=1") + "</code> — include project name in messages"
+		"• <code>" + html.EscapeString("?project=1") + "</code> — include project name in messages\n" +
+		"• <code>" + html.EscapeString("?project=path") + "</code> — include full project path in messages (for group webhooks)\n" +
+		"• <code>" + html.EscapeString("?branch=main&branch=release/*") + "</code> — filter events by branch (globs, <code>!</code> to exclude)\n" +
+		"• <code>" + html.EscapeString("?failed_jobs=1") + "</code> — alert on failed jobs with separate messages\n" +
+		"• <code>" + html.EscapeString("?emoji=1") + "</code> — report reactions to merge requests and issues\n" +
+		"• <code>" + html.EscapeString("?unknown=1") + "</code> — report unsupported events with a generic message"
 
 	s.SendMessageOrLogError(update.Message.Chat.ID, text)
 }
 
-// SendOrUpdatePipelineMessage updates an existing pipeline message or creates a new one
-func (s *GitLabTelegramService) SendOrUpdatePipelineMessage(chatID int64, pipelineURL string, text string) error {
-	ctx := context.Background()
-	pipelineUpdateKey := storage.CreatePipelineUpdateKey(pipelineURL, chatID)
-
-	// Get existing pipeline mapping
-	pipeline, err := s.pipelineStorage.GetPipeline(ctx, pipelineUpdateKey)
+// handleRevokeCommand handles the /revoke command
+func (s *GitLabTelegramService) handleRevokeCommand(ctx context.Context, b *bot.Bot, update *models.Update) {
+	webhookToken, err := s.RotateChatWebhookToken(update.Message.Chat.ID)
 	if err != nil {
-		return err
+		log.Printf("Failed to rotate webhook token for chat %d: %v", update.Message.Chat.ID, err)
+		s.SendMessageOrLogError(update.Message.Chat.ID, "⚠️ Failed to revoke the webhook URL, please try again later.")
+		return
 	}
 
-	if pipeline == nil {
-		// Pipeline not found, send new message
-		msg, err := s.SendMessageWithResult(chatID, text)
-		if err != nil {
-			return err
-		}
-
-		// Store pipeline mapping
-		pipeline := &storage.Pipeline{
-			PipelineUpdateKey: pipelineUpdateKey,
-			MessageID:         msg.ID,
-		}
-		return s.pipelineStorage.SavePipeline(ctx, pipeline)
-	} else {
-		// Update the existing message
-		if err := s.UpdateMessage(chatID, pipeline.MessageID, text); err != nil {
-			return err
-		}
-		// Update the mapping timestamp
-		return s.pipelineStorage.SavePipeline(ctx, pipeline)
-	}
+	text := "♻️ <b>Webhook URL revoked</b>\n\n" +
+		"The previous URL and secret no longer work. Update your GitLab webhook settings with the new ones.\n\n" +
+		fmt.Sprintf("🔗 <b>Your GitLab Webhook URL</b>\n\n<code>%s</code>\n\n", s.GetWebhookURL(webhookToken)) +
+		fmt.Sprintf("🔑 <b>Secret Token</b>\n\n<code>%s</code>", s.GetWebhookSecret(webhookToken))
+
+	s.SendMessageOrLogError(update.Message.Chat.ID, text)
 }
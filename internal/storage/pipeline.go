@@ -1,10 +1,13 @@
 package storage
 
 import (
+	"cmp"
 	"context"
 	"crypto/sha256"
 	"fmt"
 	"log"
+	"reflect"
+	"slices"
 	"time"
 
 	"gocloud.dev/docstore"
@@ -18,6 +21,48 @@ type Pipeline struct {
 	CreatedAt         time.Time `docstore:"created_at"`
 	UpdatedAt         time.Time `docstore:"updated_at"`
 	ExpiresAt         int64     `docstore:"expires_at"` // TTL timestamp in epoch seconds
+
+	// Last known state, to re-render the message when only a part of it changes
+	Status          string        `docstore:"status"`
+	Ref             string        `docstore:"ref"`               // Branch or tag the pipeline runs for
+	MergeRequestIID int           `docstore:"merge_request_iid"` // Merge request the pipeline runs for, if any
+	Jobs            []PipelineJob `docstore:"jobs"`
+
+	// Detects concurrent updates (see docstore revisions)
+	DocstoreRevision any
+}
+
+// PipelineJob represents the last known state of a pipeline job (or workflow run job)
+type PipelineJob struct {
+	ID       int64   `docstore:"id"`
+	Name     string  `docstore:"name"`
+	Status   string  `docstore:"status"`
+	Duration float64 `docstore:"duration"` // In seconds
+	Runner   string  `docstore:"runner"`
+	Group    string  `docstore:"group"` // Grouping in the message, e.g. the app reporting a GitHub check
+}
+
+// Job returns the job with the given ID, if it is known
+func (p *Pipeline) Job(id int64) (PipelineJob, bool) {
+	i, found := slices.BinarySearchFunc(p.Jobs, id, func(j PipelineJob, id int64) int {
+		return cmp.Compare(j.ID, id)
+	})
+	if !found {
+		return PipelineJob{}, false
+	}
+	return p.Jobs[i], true
+}
+
+// SetJob adds or replaces a job by its ID, keeping jobs ordered by ID
+func (p *Pipeline) SetJob(job PipelineJob) {
+	i, found := slices.BinarySearchFunc(p.Jobs, job.ID, func(j PipelineJob, id int64) int {
+		return cmp.Compare(j.ID, id)
+	})
+	if found {
+		p.Jobs[i] = job
+	} else {
+		p.Jobs = slices.Insert(p.Jobs, i, job)
+	}
 }
 
 // PipelineStorage handles pipeline persistence
@@ -44,7 +89,8 @@ func CreatePipelineUpdateKey(pipelineURL string, chatID int64) string {
 	return fmt.Sprintf("%x", hash)
 }
 
-// SavePipeline saves or replaces a pipeline (Put operation only)
+// SavePipeline saves or replaces a pipeline (Put operation only).
+// Fails with FailedPrecondition if the pipeline was fetched and then changed by someone else.
 func (s *PipelineStorage) SavePipeline(ctx context.Context, pipeline *Pipeline) error {
 	now := time.Now()
 	if pipeline.CreatedAt.IsZero() {
@@ -55,6 +101,19 @@ func (s *PipelineStorage) SavePipeline(ctx context.Context, pipeline *Pipeline)
 	return s.collection.Put(ctx, pipeline)
 }
 
+// IsPipelineChanged reports whether a saved pipeline has been changed by someone else since
+func (s *PipelineStorage) IsPipelineChanged(ctx context.Context, pipeline *Pipeline) (bool, error) {
+	stored := &Pipeline{PipelineUpdateKey: pipeline.PipelineUpdateKey}
+	err := s.collection.Get(ctx, stored)
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !reflect.DeepEqual(stored.DocstoreRevision, pipeline.DocstoreRevision), nil
+}
+
 // GetPipeline retrieves a pipeline by its update key with distributed lock logic
 func (s *PipelineStorage) GetPipeline(ctx context.Context, pipelineUpdateKey string) (*Pipeline, error) {
 	pipeline := &Pipeline{PipelineUpdateKey: pipelineUpdateKey}
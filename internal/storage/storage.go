@@ -19,6 +19,7 @@ import (
 type Storage struct {
 	ChatStorage     *ChatStorage
 	PipelineStorage *PipelineStorage
+	WebhookStorage  *WebhookStorage
 }
 
 // NewStorage creates a new centralized storage instance
@@ -33,9 +34,15 @@ func NewStorage(ctx context.Context) (*Storage, error) {
 		return nil, fmt.Errorf("failed to initialize pipeline storage: %w", err)
 	}
 
+	webhookStorage, err := NewWebhookStorage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize webhook storage: %w", err)
+	}
+
 	return &Storage{
 		ChatStorage:     chatStorage,
 		PipelineStorage: pipelineStorage,
+		WebhookStorage:  webhookStorage,
 	}, nil
 }
 
@@ -74,6 +81,7 @@ func (s *Storage) Close() error {
 	closers := []io.Closer{
 		s.ChatStorage,
 		s.PipelineStorage,
+		s.WebhookStorage,
 		// Add more storages here as needed
 	}
 
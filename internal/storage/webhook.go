@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gocloud.dev/docstore"
+	"gocloud.dev/gcerrors"
+)
+
+// Webhook maps an opaque webhook URL token to the chat that receives its events
+type Webhook struct {
+	Token     string    `docstore:"token"` // Partition Key (S)
+	ChatID    int64     `docstore:"chat_id"`
+	BotType   string    `docstore:"bot_type"`
+	CreatedAt time.Time `docstore:"created_at"`
+}
+
+// ChatWebhook records the current webhook token of a chat for one bot.
+// It is stored next to the tokens, under a key that can't be a token (see chatWebhookKey),
+// because on Mongo and in-memory storage the chats collection is keyed by chat ID alone.
+type ChatWebhook struct {
+	Key              string    `docstore:"token"` // Partition Key (S)
+	WebhookToken     string    `docstore:"webhook_token"`
+	UpdatedAt        time.Time `docstore:"updated_at"`
+	DocstoreRevision any
+}
+
+// chatWebhookKey returns the key of a chat's webhook record. Tokens are base64url-encoded,
+// so they never contain a colon.
+func chatWebhookKey(botType string, chatID int64) string {
+	return fmt.Sprintf("chat:%s:%d", botType, chatID)
+}
+
+// WebhookStorage handles webhook token persistence
+type WebhookStorage struct {
+	collection *docstore.Collection
+}
+
+// NewWebhookStorage creates a new webhook storage instance
+func NewWebhookStorage(ctx context.Context) (*WebhookStorage, error) {
+	collection, err := openCollection(ctx, "webhooks", "token", "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebhookStorage{
+		collection: collection,
+	}, nil
+}
+
+// CreateWebhook stores a new webhook token, failing if the token is already taken
+func (s *WebhookStorage) CreateWebhook(ctx context.Context, webhook *Webhook) error {
+	webhook.CreatedAt = time.Now()
+	return s.collection.Create(ctx, webhook)
+}
+
+// GetWebhook retrieves a webhook by its token, returning nil if it doesn't exist
+func (s *WebhookStorage) GetWebhook(ctx context.Context, token string) (*Webhook, error) {
+	// Chat webhook records must not resolve as tokens
+	if strings.Contains(token, ":") {
+		return nil, nil
+	}
+
+	webhook := &Webhook{Token: token}
+	err := s.collection.Get(ctx, webhook)
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook deletes a webhook token; deleting a missing token is not an error
+func (s *WebhookStorage) DeleteWebhook(ctx context.Context, token string) error {
+	err := s.collection.Delete(ctx, &Webhook{Token: token})
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		return nil
+	}
+	return err
+}
+
+// GetChatWebhook retrieves the chat's webhook record for the bot, returning nil if it doesn't exist
+func (s *WebhookStorage) GetChatWebhook(ctx context.Context, botType string, chatID int64) (*ChatWebhook, error) {
+	chatWebhook := &ChatWebhook{Key: chatWebhookKey(botType, chatID)}
+	err := s.collection.Get(ctx, chatWebhook)
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return chatWebhook, nil
+}
+
+// CreateChatWebhook records the chat's webhook token for the bot,
+// failing if the chat already has one
+func (s *WebhookStorage) CreateChatWebhook(ctx context.Context, botType string, chatID int64, token string) error {
+	return s.collection.Create(ctx, &ChatWebhook{
+		Key:          chatWebhookKey(botType, chatID),
+		WebhookToken: token,
+		UpdatedAt:    time.Now(),
+	})
+}
+
+// ReplaceChatWebhookToken replaces the token of a previously retrieved chat webhook record,
+// failing if the record has been changed or deleted since
+func (s *WebhookStorage) ReplaceChatWebhookToken(ctx context.Context, chatWebhook *ChatWebhook, token string) error {
+	chatWebhook.WebhookToken = token
+	chatWebhook.UpdatedAt = time.Now()
+	return s.collection.Replace(ctx, chatWebhook)
+}
+
+// DeleteChatWebhook deletes the chat's webhook record for the bot; deleting a missing record is not an error
+func (s *WebhookStorage) DeleteChatWebhook(ctx context.Context, botType string, chatID int64) error {
+	err := s.collection.Delete(ctx, &ChatWebhook{Key: chatWebhookKey(botType, chatID)})
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		return nil
+	}
+	return err
+}
+
+// Close closes the storage connection
+func (s *WebhookStorage) Close() error {
+	if s.collection != nil {
+		return s.collection.Close()
+	}
+	return nil
+}
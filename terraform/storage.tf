@@ -43,6 +43,23 @@ resource "aws_dynamodb_table" "pipelines" {
   }
 }
 
+# DynamoDB table for storing webhook URL tokens
+resource "aws_dynamodb_table" "webhooks" {
+  name         = "${local.function_name}-webhooks"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "token"
+
+  attribute {
+    name = "token"
+    type = "S" # String (random token from the webhook URL)
+  }
+
+  tags = {
+    Name        = "${local.function_name}-webhooks"
+    Environment = terraform.workspace
+  }
+}
+
 # IAM policy for DynamoDB access
 resource "aws_iam_policy" "dynamodb_policy" {
   name        = "${local.function_name}-dynamodb-policy"
@@ -67,7 +84,9 @@ resource "aws_iam_policy" "dynamodb_policy" {
           aws_dynamodb_table.chats.arn,
           "${aws_dynamodb_table.chats.arn}/*",
           aws_dynamodb_table.pipelines.arn,
-          "${aws_dynamodb_table.pipelines.arn}/*"
+          "${aws_dynamodb_table.pipelines.arn}/*",
+          aws_dynamodb_table.webhooks.arn,
+          "${aws_dynamodb_table.webhooks.arn}/*"
         ]
       }
     ]